@@ -1,37 +1,60 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 	"strings"
 )
 
 // GetAccounts - get array of accounts and their endpoints
 func (r *Redfish) GetAccounts() ([]string, error) {
-	var accsvc AccountService
-	var accs OData
+	return r.GetAccountsContext(context.Background())
+}
+
+// GetAccountsContext - same as GetAccounts but uses the supplied context for all HTTP requests
+func (r *Redfish) GetAccountsContext(ctx context.Context) ([]string, error) {
 	var result = make([]string, 0)
 
+	endpoint, err := r.getAccountsEndpoint(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	result, err = r.GetCollectionMembersContext(ctx, endpoint)
+	if err != nil {
+		return result, err
+	}
+
+	if len(result) == 0 {
+		return result, fmt.Errorf("BUG: Missing or empty Members attribute in Accounts")
+	}
+	return result, nil
+}
+
+// get endpoint of the accounts collection from the account service
+func (r *Redfish) getAccountsEndpoint(ctx context.Context) (string, error) {
+	var accsvc AccountService
+
 	// check if vendor supports account management
 	if r.Flavor == RedfishFlavorNotInitialized {
-		err := r.GetVendorFlavor()
+		err := r.GetVendorFlavorContext(ctx)
 		if err != nil {
-			return result, err
+			return "", err
 		}
 	}
 	if VendorCapabilities[r.FlavorString]&HasAccountService != HasAccountService {
-		return result, errors.New("Account management is not support for this vendor")
+		return "", r.newNotSupportedError("Account management is not supported for this vendor")
 	}
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return result, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return "", ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -40,88 +63,58 @@ func (r *Redfish) GetAccounts() ([]string, error) {
 			"path":               r.AccountService,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to account service")
 	}
-	response, err := r.httpRequest(r.AccountService, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, r.AccountService, "GET", nil, nil, false)
 	if err != nil {
-		return result, err
+		return "", err
 	}
 
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return result, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return "", r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &accsvc)
 	if err != nil {
-		return result, err
-	}
-
-	if accsvc.AccountsEndpoint == nil {
-		return result, errors.New("BUG: No Accounts endpoint found")
-	}
-
-	if r.Verbose {
-		log.WithFields(log.Fields{
-			"hostname":           r.Hostname,
-			"port":               r.Port,
-			"timeout":            r.Timeout,
-			"flavor":             r.Flavor,
-			"flavor_string":      r.FlavorString,
-			"path":               *accsvc.AccountsEndpoint.ID,
-			"method":             "GET",
-			"additional_headers": nil,
-			"use_basic_auth":     false,
-		}).Info("Requesting accounts")
-	}
-	response, err = r.httpRequest(*accsvc.AccountsEndpoint.ID, "GET", nil, nil, false)
-	if err != nil {
-		return result, err
-	}
-
-	raw = response.Content
-	if response.StatusCode != http.StatusOK {
-		return result, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
-	}
-
-	err = json.Unmarshal(raw, &accs)
-	if err != nil {
-		return result, err
+		return "", err
 	}
 
-	if len(accs.Members) == 0 {
-		return result, fmt.Errorf("BUG: Missing or empty Members attribute in Accounts")
+	if accsvc.AccountsEndpoint == nil || accsvc.AccountsEndpoint.ID == nil {
+		return "", errors.New("BUG: No Accounts endpoint found")
 	}
 
-	for _, a := range accs.Members {
-		result = append(result, *a.ID)
-	}
-	return result, nil
+	return *accsvc.AccountsEndpoint.ID, nil
 }
 
 // GetAccountData - get account data for a particular account
 func (r *Redfish) GetAccountData(accountEndpoint string) (*AccountData, error) {
+	return r.GetAccountDataContext(context.Background(), accountEndpoint)
+}
+
+// GetAccountDataContext - same as GetAccountData but uses the supplied context for all HTTP requests
+func (r *Redfish) GetAccountDataContext(ctx context.Context, accountEndpoint string) (*AccountData, error) {
 	var result AccountData
 
 	// check if vendor supports account management
 	if r.Flavor == RedfishFlavorNotInitialized {
-		err := r.GetVendorFlavor()
+		err := r.GetVendorFlavorContext(ctx)
 		if err != nil {
 			return nil, err
 		}
 	}
 	if VendorCapabilities[r.FlavorString]&HasAccountService != HasAccountService {
-		return nil, errors.New("Account management is not support for this vendor")
+		return nil, r.newNotSupportedError("Account management is not supported for this vendor")
 	}
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return nil, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -130,10 +123,10 @@ func (r *Redfish) GetAccountData(accountEndpoint string) (*AccountData, error) {
 			"path":               accountEndpoint,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting account information")
 	}
-	response, err := r.httpRequest(accountEndpoint, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, accountEndpoint, "GET", nil, nil, false)
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +135,7 @@ func (r *Redfish) GetAccountData(accountEndpoint string) (*AccountData, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return nil, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &result)
@@ -150,24 +143,55 @@ func (r *Redfish) GetAccountData(accountEndpoint string) (*AccountData, error) {
 		return nil, err
 	}
 	result.SelfEndpoint = &accountEndpoint
+	result.ETag = etagFromResult(response, result.ETag)
 	return &result, nil
 }
 
-// MapAccountsByName - map username -> user data
-func (r *Redfish) MapAccountsByName() (map[string]*AccountData, error) {
-	var result = make(map[string]*AccountData)
+// get data of all accounts, by a single request if the service processor supports $expand
+func (r *Redfish) getAllAccountData(ctx context.Context) ([]*AccountData, error) {
+	var result = make([]*AccountData, 0)
+
+	endpoint, err := r.getAccountsEndpoint(ctx)
+	if err != nil {
+		return result, err
+	}
 
-	al, err := r.GetAccounts()
+	members, err := r.getCollectionContent(ctx, endpoint)
 	if err != nil {
 		return result, err
 	}
 
-	for _, acc := range al {
-		a, err := r.GetAccountData(acc)
+	for i := range members {
+		var d AccountData
+
+		err = json.Unmarshal(members[i].content, &d)
 		if err != nil {
 			return result, err
 		}
 
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// MapAccountsByName - map username -> user data
+func (r *Redfish) MapAccountsByName() (map[string]*AccountData, error) {
+	return r.MapAccountsByNameContext(context.Background())
+}
+
+// MapAccountsByNameContext - same as MapAccountsByName but uses the supplied context for all HTTP requests
+func (r *Redfish) MapAccountsByNameContext(ctx context.Context) (map[string]*AccountData, error) {
+	var result = make(map[string]*AccountData)
+
+	dl, err := r.getAllAccountData(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, a := range dl {
 		// should NEVER happen
 		if a.UserName == nil {
 			return result, errors.New("BUG: No UserName found or UserName is null")
@@ -176,7 +200,7 @@ func (r *Redfish) MapAccountsByName() (map[string]*AccountData, error) {
 		//       and report an empty UserName for unused accounts "slots"
 		if *a.UserName == "" {
 			if r.Verbose {
-				log.WithFields(log.Fields{
+				r.logger().WithFields(LogFields{
 					"hostname":      r.Hostname,
 					"port":          r.Port,
 					"timeout":       r.Timeout,
@@ -195,19 +219,19 @@ func (r *Redfish) MapAccountsByName() (map[string]*AccountData, error) {
 
 // MapAccountsByID - map ID -> user data
 func (r *Redfish) MapAccountsByID() (map[string]*AccountData, error) {
+	return r.MapAccountsByIDContext(context.Background())
+}
+
+// MapAccountsByIDContext - same as MapAccountsByID but uses the supplied context for all HTTP requests
+func (r *Redfish) MapAccountsByIDContext(ctx context.Context) (map[string]*AccountData, error) {
 	var result = make(map[string]*AccountData)
 
-	al, err := r.GetAccounts()
+	dl, err := r.getAllAccountData(ctx)
 	if err != nil {
 		return result, err
 	}
 
-	for _, acc := range al {
-		a, err := r.GetAccountData(acc)
-		if err != nil {
-			return result, err
-		}
-
+	for _, a := range dl {
 		// should NEVER happen
 		if a.ID == nil {
 			return result, errors.New("BUG: No Id found or Id is null")
@@ -219,9 +243,9 @@ func (r *Redfish) MapAccountsByID() (map[string]*AccountData, error) {
 }
 
 // get endpoint of first free account slot
-func (r *Redfish) dellGetFreeAccountSlot() (string, error) {
+func (r *Redfish) dellGetFreeAccountSlot(ctx context.Context) (string, error) {
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":      r.Hostname,
 			"port":          r.Port,
 			"timeout":       r.Timeout,
@@ -231,7 +255,7 @@ func (r *Redfish) dellGetFreeAccountSlot() (string, error) {
 		}).Info("Looking for unused account slot")
 	}
 
-	accountList, err := r.GetAccounts()
+	accountList, err := r.GetAccountsContext(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -245,7 +269,7 @@ func (r *Redfish) dellGetFreeAccountSlot() (string, error) {
 			continue
 		}
 
-		_acd, err := r.GetAccountData(accEndpt)
+		_acd, err := r.GetAccountDataContext(ctx, accEndpt)
 		if err != nil {
 			return "", err
 		}
@@ -257,7 +281,7 @@ func (r *Redfish) dellGetFreeAccountSlot() (string, error) {
 
 		if *_acd.UserName == "" {
 			if r.Verbose {
-				log.WithFields(log.Fields{
+				r.logger().WithFields(LogFields{
 					"hostname":      r.Hostname,
 					"port":          r.Port,
 					"timeout":       r.Timeout,
@@ -274,10 +298,10 @@ func (r *Redfish) dellGetFreeAccountSlot() (string, error) {
 	return "", nil
 }
 
-func (r *Redfish) dellAddAccount(acd AccountCreateData) error {
+func (r *Redfish) dellAddAccount(ctx context.Context, acd AccountCreateData) error {
 	var accountEnabled bool
 
-	_unusedSlot, err := r.dellGetFreeAccountSlot()
+	_unusedSlot, err := r.dellGetFreeAccountSlot(ctx)
 	if err != nil {
 		return err
 	}
@@ -289,7 +313,7 @@ func (r *Redfish) dellAddAccount(acd AccountCreateData) error {
 	// Instead of adding an account we have to modify an existing
 	// unused account slot.
 	acd.Enabled = &accountEnabled
-	return r.ModifyAccountByEndpoint(_unusedSlot, acd)
+	return r.ModifyAccountByEndpointContext(ctx, _unusedSlot, acd)
 }
 
 func (r *Redfish) hpBuildPrivilegeMap(flags uint) *AccountPrivilegeMapOemHp {
@@ -323,18 +347,23 @@ func (r *Redfish) hpBuildPrivilegeMap(flags uint) *AccountPrivilegeMapOemHp {
 
 // AddAccount - Add account
 func (r *Redfish) AddAccount(acd AccountCreateData) error {
+	return r.AddAccountContext(context.Background(), acd)
+}
+
+// AddAccountContext - same as AddAccount but uses the supplied context for all HTTP requests
+func (r *Redfish) AddAccountContext(ctx context.Context, acd AccountCreateData) error {
 	var acsd AccountService
 	var accep string
 	var payload string
 	var _flags uint
 	var found bool
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
 	}
 
 	if r.Flavor == RedfishFlavorNotInitialized {
-		err := r.GetVendorFlavor()
+		err := r.GetVendorFlavorContext(ctx)
 		if err != nil {
 			return err
 		}
@@ -342,18 +371,18 @@ func (r *Redfish) AddAccount(acd AccountCreateData) error {
 
 	// check if vendor supports account management
 	if VendorCapabilities[r.FlavorString]&HasAccountService != HasAccountService {
-		return errors.New("Account management is not support for this vendor")
+		return r.newNotSupportedError("Account management is not supported for this vendor")
 	}
 
 	// Note: DELL/EMC iDRAC uses a hardcoded, predefined number of account slots
 	//       and as a consequence only support GET and HEAD on the "usual" endpoints
 	if r.Flavor == RedfishDell {
-		return r.dellAddAccount(acd)
+		return r.dellAddAccount(ctx, acd)
 	}
 
 	// get Accounts endpoint from AccountService
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -362,16 +391,16 @@ func (r *Redfish) AddAccount(acd AccountCreateData) error {
 			"path":               r.AccountService,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to account service")
 	}
-	response, err := r.httpRequest(r.AccountService, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, r.AccountService, "GET", nil, nil, false)
 	if err != nil {
-		return nil
+		return err
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(response.Content, &acsd)
@@ -396,7 +425,7 @@ func (r *Redfish) AddAccount(acd AccountCreateData) error {
 
 		// OemHpPrivilegeMap is an INTERNAL map but it MUST be exported to be accessed by json.Marshal
 		if acd.OemHpPrivilegeMap != nil {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":          r.Hostname,
 				"port":              r.Port,
 				"timeout":           r.Timeout,
@@ -425,19 +454,17 @@ func (r *Redfish) AddAccount(acd AccountCreateData) error {
 			acd.OemHpPrivilegeMap = r.hpBuildPrivilegeMap(acd.HPEPrivileges)
 		}
 
-		rawPrivPayload, err := json.Marshal(*acd.OemHpPrivilegeMap)
+		payload, err = r.hpMakeAccountPayload(acd)
 		if err != nil {
 			return err
 		}
-
-		payload = fmt.Sprintf("{ \"UserName\": \"%s\", \"Password\": \"%s\", \"Oem\":{ \"Hp\":{ \"LoginName\": \"%s\", \"Privileges\": %s }}}", acd.UserName, acd.Password, acd.UserName, string(rawPrivPayload))
 	} else {
 		if acd.UserName == "" || acd.Password == "" || acd.Role == "" {
 			return errors.New("Required field(s) missing")
 		}
 
 		// check of requested role exists, role Names are _NOT_ unique (e.g. Supermicro report all names as "User Role") but Id is
-		rmap, err := r.MapRolesByID()
+		rmap, err := r.MapRolesByIDContext(ctx)
 		if err != nil {
 			return err
 		}
@@ -447,11 +474,14 @@ func (r *Redfish) AddAccount(acd AccountCreateData) error {
 			return fmt.Errorf("Requested role %s not found", acd.Role)
 		}
 
-		payload = fmt.Sprintf("{ \"UserName\": \"%s\", \"Password\": \"%s\", \"RoleId\": \"%s\" }", acd.UserName, acd.Password, acd.Role)
+		payload, err = r.makeAccountPayloadVanilla(acd)
+		if err != nil {
+			return err
+		}
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -460,11 +490,11 @@ func (r *Redfish) AddAccount(acd AccountCreateData) error {
 			"path":               accep,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Adding account")
 	}
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -473,63 +503,26 @@ func (r *Redfish) AddAccount(acd AccountCreateData) error {
 			"path":               accep,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
-			"payload":            payload,
+			"use_basic_auth":     r.UseBasicAuth,
+			"payload":            redactPayload(payload),
 		}).Debug("Adding account")
 	}
-	response, err = r.httpRequest(accep, "POST", nil, strings.NewReader(payload), false)
+	response, err = r.httpRequest(ctx, accep, "POST", nil, strings.NewReader(payload), false)
 	if err != nil {
 		return err
 	}
 
-	// some vendors like Supermicro imposes limits on fields like password and return HTTP 400 - Bad Request
-	if response.StatusCode == http.StatusBadRequest {
-		rerr, err := r.ProcessError(response)
-		if err != nil {
-			return fmt.Errorf("Operation failed, returned \"%s\" and no error information", response.Status)
-		}
-
-		//
-		// For instance Supermicro responds for creation with passwords exceeding the maximal password length with:
-		// {
-		//   "error": {
-		// 	"code": "Base.v1_4_0.GeneralError",
-		// 	"Message": "A general error has occurred. See ExtendedInfo for more information.",
-		// 	"@Message.ExtendedInfo": [
-		// 	  {
-		// 		"MessageId": "Base.v1_4_0.PropertyValueFormatError",
-		// 		"Severity": "Warning",
-		// 		"Resolution": "Correct the value for the property in the request body and resubmit the request if the operation failed.",
-		// 		"Message": "The value this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long. for the property Password is of a different format than the property can accept.",
-		// 		"MessageArgs": [
-		// 		  "this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.",
-		// 		  "Password"
-		// 		],
-		// 		"RelatedProperties": [
-		// 		  "Password"
-		// 		]
-		// 	  }
-		// 	]
-		//   }
-		// }
-		//
-		errmsg := r.GetErrorMessage(rerr)
-		if errmsg != "" {
-			return fmt.Errorf("%s", errmsg)
-		}
-		return fmt.Errorf("Operation failed, returned \"%s\" and no error information", response.Status)
-	}
-
-	// any other error ? (HTTP 400 has been handled above)
-	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusBadRequest {
-		return fmt.Errorf("Operation failed, returned \"%s\" and no error information", response.Status)
+	// some vendors like Supermicro imposes limits on fields like password and return HTTP 400 - Bad Request,
+	// the error object returned by the service processor is part of the HTTPError
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return r.newHTTPError("POST", response, http.StatusOK, http.StatusCreated)
 	}
 	return nil
 }
 
-func (r *Redfish) dellDeleteAccount(endpoint string) error {
+func (r *Redfish) dellDeleteAccount(ctx context.Context, endpoint string) error {
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -538,11 +531,11 @@ func (r *Redfish) dellDeleteAccount(endpoint string) error {
 			"path":               endpoint,
 			"method":             "PATCH",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Releasing DELL/EMC account slot")
 	}
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -551,41 +544,46 @@ func (r *Redfish) dellDeleteAccount(endpoint string) error {
 			"path":               endpoint,
 			"method":             "PATCH",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
-			"payload":            DELLEmptyAccountSlot,
+			"use_basic_auth":     r.UseBasicAuth,
+			"payload":            redactPayload(DELLEmptyAccountSlot),
 		}).Info("Releasing DELL/EMC account slot")
 	}
 
-	response, err := r.httpRequest(endpoint, "PATCH", nil, strings.NewReader(DELLEmptyAccountSlot), false)
-	if r.Debug {
+	response, err := r.httpRequest(ctx, endpoint, "PATCH", nil, strings.NewReader(DELLEmptyAccountSlot), false)
+	if err != nil {
+		return err
 	}
 
 	if response.StatusCode != http.StatusOK {
-		// TODO: Check error object
-		return fmt.Errorf("HTTP PATCH for %s returned \"%s\"", endpoint, response.Status)
+		return r.newHTTPError("PATCH", response, http.StatusOK)
 	}
-	return err
+	return nil
 }
 
 // DeleteAccount - delete an account
 func (r *Redfish) DeleteAccount(u string) error {
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return errors.New("No authentication token found, is the session setup correctly?")
+	return r.DeleteAccountContext(context.Background(), u)
+}
+
+// DeleteAccountContext - same as DeleteAccount but uses the supplied context for all HTTP requests
+func (r *Redfish) DeleteAccountContext(ctx context.Context, u string) error {
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
 	}
 
 	// check if vendor supports account management
 	if r.Flavor == RedfishFlavorNotInitialized {
-		err := r.GetVendorFlavor()
+		err := r.GetVendorFlavorContext(ctx)
 		if err != nil {
 			return err
 		}
 	}
 	if VendorCapabilities[r.FlavorString]&HasAccountService != HasAccountService {
-		return errors.New("Account management is not support for this vendor")
+		return r.newNotSupportedError("Account management is not supported for this vendor")
 	}
 
 	// get endpoint for account to delete
-	amap, err := r.MapAccountsByName()
+	amap, err := r.MapAccountsByNameContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -600,7 +598,7 @@ func (r *Redfish) DeleteAccount(u string) error {
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -609,21 +607,21 @@ func (r *Redfish) DeleteAccount(u string) error {
 			"path":               *adata.SelfEndpoint,
 			"method":             "DELETE",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Deleting account")
 	}
 
 	// Note: DELL/EMC only
 	if r.Flavor == RedfishDell {
-		return r.dellDeleteAccount(*adata.SelfEndpoint)
+		return r.dellDeleteAccount(ctx, *adata.SelfEndpoint)
 	}
 
-	response, err := r.httpRequest(*adata.SelfEndpoint, "DELETE", nil, nil, false)
+	response, err := r.httpRequest(ctx, *adata.SelfEndpoint, "DELETE", nil, nil, false)
 	if err != nil {
 		return err
 	}
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP DELETE for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return r.newHTTPError("DELETE", response, http.StatusOK)
 	}
 
 	return nil
@@ -631,6 +629,22 @@ func (r *Redfish) DeleteAccount(u string) error {
 
 // ChangePassword - change account password
 func (r *Redfish) ChangePassword(u string, p string) error {
+	return r.ChangePasswordContext(context.Background(), u, p)
+}
+
+// ChangePasswordContext - same as ChangePassword but uses the supplied context for all HTTP requests
+func (r *Redfish) ChangePasswordContext(ctx context.Context, u string, p string) error {
+	return r.ChangePasswordIfMatchContext(ctx, u, p, "")
+}
+
+// ChangePasswordIfMatch - same as ChangePassword but the password is only changed if the account has not been modified
+// since the entity tag etag (e.g. ETag of AccountData) was read. If etag is empty the entity tag of the current account data is used.
+func (r *Redfish) ChangePasswordIfMatch(u string, p string, etag string) error {
+	return r.ChangePasswordIfMatchContext(context.Background(), u, p, etag)
+}
+
+// ChangePasswordIfMatchContext - same as ChangePasswordIfMatch but uses the supplied context for all HTTP requests
+func (r *Redfish) ChangePasswordIfMatchContext(ctx context.Context, u string, p string, etag string) error {
 	var payload string
 
 	if u == "" {
@@ -641,24 +655,25 @@ func (r *Redfish) ChangePassword(u string, p string) error {
 		return fmt.Errorf("Password for %s is empty", u)
 	}
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
 	}
 
 	// check if vendor supports account management
 	if r.Flavor == RedfishFlavorNotInitialized {
-		err := r.GetVendorFlavor()
+		err := r.GetVendorFlavorContext(ctx)
 		if err != nil {
 			return err
 		}
 	}
 	if VendorCapabilities[r.FlavorString]&HasAccountService != HasAccountService {
-		return errors.New("Account management is not support for this vendor")
+		return r.newNotSupportedError("Account management is not supported for this vendor")
 	}
 
 	// check if the account exists
-	amap, err := r.MapAccountsByName()
+	amap, err := r.MapAccountsByNameContext(ctx)
 	if err != nil {
+		return err
 	}
 
 	adata, found := amap[u]
@@ -669,10 +684,20 @@ func (r *Redfish) ChangePassword(u string, p string) error {
 	if adata.SelfEndpoint == nil || *adata.SelfEndpoint == "" {
 		return fmt.Errorf("BUG: SelfEndpoint not set or empty in account data for %s", u)
 	}
-	payload = fmt.Sprintf("{ \"Password\": \"%s\" }", p)
+	raw, err := json.Marshal(accountPasswordPayload{Password: p})
+	if err != nil {
+		return err
+	}
+	payload = string(raw)
+
+	// don't overwrite concurrent changes of the account, an entity tag supplied by the caller takes precedence
+	if etag == "" && adata.ETag != nil {
+		etag = *adata.ETag
+	}
+	header := ifMatchHeader(etag)
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -680,12 +705,12 @@ func (r *Redfish) ChangePassword(u string, p string) error {
 			"flavor_string":      r.FlavorString,
 			"path":               *adata.SelfEndpoint,
 			"method":             "PATCH",
-			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"additional_headers": header,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Changing account password")
 	}
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -693,57 +718,20 @@ func (r *Redfish) ChangePassword(u string, p string) error {
 			"flavor_string":      r.FlavorString,
 			"path":               *adata.SelfEndpoint,
 			"method":             "PATCH",
-			"additional_headers": nil,
-			"use_basic_auth":     false,
-			"payload":            payload,
+			"additional_headers": header,
+			"use_basic_auth":     r.UseBasicAuth,
+			"payload":            redactPayload(payload),
 		}).Debug("Changing account password")
 	}
-	response, err := r.httpRequest(*adata.SelfEndpoint, "PATCH", nil, strings.NewReader(payload), false)
+	response, err := r.httpRequest(ctx, *adata.SelfEndpoint, "PATCH", header, strings.NewReader(payload), false)
 	if err != nil {
 		return err
 	}
 
-	// some vendors like Supermicro imposes limits on fields like password and return HTTP 400 - Bad Request
-	if response.StatusCode == http.StatusBadRequest {
-		rerr, err := r.ProcessError(response)
-		if err != nil {
-			return fmt.Errorf("Operation failed, returned \"%s\" and no error information", response.Status)
-		}
-
-		//
-		// For instance Supermicro responds for creation with passwords exceeding the maximal password length with:
-		// {
-		//   "error": {
-		// 	"code": "Base.v1_4_0.GeneralError",
-		// 	"Message": "A general error has occurred. See ExtendedInfo for more information.",
-		// 	"@Message.ExtendedInfo": [
-		// 	  {
-		// 		"MessageId": "Base.v1_4_0.PropertyValueFormatError",
-		// 		"Severity": "Warning",
-		// 		"Resolution": "Correct the value for the property in the request body and resubmit the request if the operation failed.",
-		// 		"Message": "The value this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long. for the property Password is of a different format than the property can accept.",
-		// 		"MessageArgs": [
-		// 		  "this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.this_password_is_too_long.",
-		// 		  "Password"
-		// 		],
-		// 		"RelatedProperties": [
-		// 		  "Password"
-		// 		]
-		// 	  }
-		// 	]
-		//   }
-		// }
-		//
-		errmsg := r.GetErrorMessage(rerr)
-		if errmsg != "" {
-			return fmt.Errorf("%s", errmsg)
-		}
-		return fmt.Errorf("Operation failed, returned \"%s\" and no error information", response.Status)
-	}
-
-	// any other error ? (HTTP 400 has been handled above)
-	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusBadRequest {
-		return fmt.Errorf("HTTP POST for %s returned \"%s\" instead of \"200 OK\" or \"201 Created\"", response.URL, response.Status)
+	// some vendors like Supermicro imposes limits on fields like password and return HTTP 400 - Bad Request,
+	// the error object returned by the service processor is part of the HTTPError
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return r.newHTTPError("PATCH", response, http.StatusOK, http.StatusCreated)
 	}
 	return nil
 }
@@ -757,7 +745,7 @@ func (r *Redfish) makeAccountCreateModifyPayload(acd AccountCreateData) (string,
 	if r.Flavor == RedfishHP || r.Flavor == RedfishHPE {
 		// OemHpPrivilegeMap is an INTERNAL map but it MUST be exported to be accessed by json.Marshal
 		if acd.OemHpPrivilegeMap != nil {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":          r.Hostname,
 				"port":              r.Port,
 				"timeout":           r.Timeout,
@@ -784,12 +772,7 @@ func (r *Redfish) makeAccountCreateModifyPayload(acd AccountCreateData) (string,
 			acd.OemHpPrivilegeMap = r.hpBuildPrivilegeMap(_flags)
 		}
 
-		rawPrivPayload, err := json.Marshal(*acd.OemHpPrivilegeMap)
-		if err != nil {
-			return "", err
-		}
-
-		payload = fmt.Sprintf("{ \"UserName\": \"%s\", \"Password\": \"%s\", \"Oem\":{ \"Hp\":{ \"LoginName\": \"%s\", \"Privileges\": %s }}}", acd.UserName, acd.Password, acd.UserName, string(rawPrivPayload))
+		return r.hpMakeAccountPayload(acd)
 	} else {
 		// force exclustion of privilege map for non-HP(E) systems
 		acd.OemHpPrivilegeMap = nil
@@ -802,25 +785,57 @@ func (r *Redfish) makeAccountCreateModifyPayload(acd AccountCreateData) (string,
 	return payload, nil
 }
 
+func (r *Redfish) makeAccountPayloadVanilla(acd AccountCreateData) (string, error) {
+	raw, err := json.Marshal(accountCreatePayload{
+		UserName: acd.UserName,
+		Password: acd.Password,
+		RoleID:   acd.Role,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+func (r *Redfish) hpMakeAccountPayload(acd AccountCreateData) (string, error) {
+	var payload accountCreateModifyPayloadHp
+
+	payload.UserName = acd.UserName
+	payload.Password = acd.Password
+	payload.Oem.Hp.LoginName = acd.UserName
+	payload.Oem.Hp.Privileges = acd.OemHpPrivilegeMap
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
 // ModifyAccount - modify an account
 func (r *Redfish) ModifyAccount(u string, acd AccountCreateData) error {
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return errors.New("No authentication token found, is the session setup correctly?")
+	return r.ModifyAccountContext(context.Background(), u, acd)
+}
+
+// ModifyAccountContext - same as ModifyAccount but uses the supplied context for all HTTP requests
+func (r *Redfish) ModifyAccountContext(ctx context.Context, u string, acd AccountCreateData) error {
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
 	}
 
 	// check if vendor supports account management
 	if r.Flavor == RedfishFlavorNotInitialized {
-		err := r.GetVendorFlavor()
+		err := r.GetVendorFlavorContext(ctx)
 		if err != nil {
 			return err
 		}
 	}
 	if VendorCapabilities[r.FlavorString]&HasAccountService != HasAccountService {
-		return errors.New("Account management is not support for this vendor")
+		return r.newNotSupportedError("Account management is not supported for this vendor")
 	}
 
 	// get endpoint for account to modify/check if account with this name already exists
-	umap, err := r.MapAccountsByName()
+	umap, err := r.MapAccountsByNameContext(ctx)
 	if err != nil {
 		return err
 	}
@@ -838,8 +853,15 @@ func (r *Redfish) ModifyAccount(u string, acd AccountCreateData) error {
 		return err
 	}
 
+	// don't overwrite concurrent changes of the account, an entity tag supplied by the caller takes precedence
+	etag := acd.ETag
+	if etag == "" && udata.ETag != nil {
+		etag = *udata.ETag
+	}
+	header := ifMatchHeader(etag)
+
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -847,12 +869,12 @@ func (r *Redfish) ModifyAccount(u string, acd AccountCreateData) error {
 			"flavor_string":      r.FlavorString,
 			"path":               *udata.SelfEndpoint,
 			"method":             "PATCH",
-			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"additional_headers": header,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Modifying account")
 	}
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -860,52 +882,44 @@ func (r *Redfish) ModifyAccount(u string, acd AccountCreateData) error {
 			"flavor_string":      r.FlavorString,
 			"path":               *udata.SelfEndpoint,
 			"method":             "PATCH",
-			"additional_headers": nil,
-			"use_basic_auth":     false,
-			"payload":            payload,
+			"additional_headers": header,
+			"use_basic_auth":     r.UseBasicAuth,
+			"payload":            redactPayload(payload),
 		}).Debug("Modifying account")
 	}
-	response, err := r.httpRequest(*udata.SelfEndpoint, "PATCH", nil, strings.NewReader(payload), false)
+	response, err := r.httpRequest(ctx, *udata.SelfEndpoint, "PATCH", header, strings.NewReader(payload), false)
 	if err != nil {
 		return err
 	}
 
-	// some vendors like Supermicro imposes limits on fields like password and return HTTP 400 - Bad Request
-	if response.StatusCode == http.StatusBadRequest {
-		rerr, err := r.ProcessError(response)
-		if err != nil {
-			return fmt.Errorf("Operation failed, returned \"%s\" and no error information", response.Status)
-		}
-
-		errmsg := r.GetErrorMessage(rerr)
-		if errmsg != "" {
-			return fmt.Errorf("%s", errmsg)
-		}
-		return fmt.Errorf("Operation failed, returned \"%s\" and no error information", response.Status)
-	}
-
-	// any other error ? (HTTP 400 has been handled above)
-	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusBadRequest {
-		return fmt.Errorf("HTTP POST for %s returned \"%s\" instead of \"200 OK\" or \"201 Created\"", response.URL, response.Status)
+	// some vendors like Supermicro imposes limits on fields like password and return HTTP 400 - Bad Request,
+	// the error object returned by the service processor is part of the HTTPError
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return r.newHTTPError("PATCH", response, http.StatusOK, http.StatusCreated)
 	}
 	return nil
 }
 
 // ModifyAccountByEndpoint - modify account by it's endpoint
 func (r *Redfish) ModifyAccountByEndpoint(endpoint string, acd AccountCreateData) error {
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return errors.New("No authentication token found, is the session setup correctly?")
+	return r.ModifyAccountByEndpointContext(context.Background(), endpoint, acd)
+}
+
+// ModifyAccountByEndpointContext - same as ModifyAccountByEndpoint but uses the supplied context for all HTTP requests
+func (r *Redfish) ModifyAccountByEndpointContext(ctx context.Context, endpoint string, acd AccountCreateData) error {
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
 	}
 
 	// check if vendor supports account management
 	if r.Flavor == RedfishFlavorNotInitialized {
-		err := r.GetVendorFlavor()
+		err := r.GetVendorFlavorContext(ctx)
 		if err != nil {
 			return err
 		}
 	}
 	if VendorCapabilities[r.FlavorString]&HasAccountService != HasAccountService {
-		return errors.New("Account management is not support for this vendor")
+		return r.newNotSupportedError("Account management is not supported for this vendor")
 	}
 
 	if r.Flavor == RedfishHP || r.Flavor == RedfishHPE {
@@ -916,9 +930,10 @@ func (r *Redfish) ModifyAccountByEndpoint(endpoint string, acd AccountCreateData
 		if err != nil {
 			return err
 		}
+		header := ifMatchHeader(acd.ETag)
 
 		if r.Verbose {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":           r.Hostname,
 				"port":               r.Port,
 				"timeout":            r.Timeout,
@@ -926,12 +941,12 @@ func (r *Redfish) ModifyAccountByEndpoint(endpoint string, acd AccountCreateData
 				"flavor_string":      r.FlavorString,
 				"path":               endpoint,
 				"method":             "PATCH",
-				"additional_headers": nil,
-				"use_basic_auth":     false,
+				"additional_headers": header,
+				"use_basic_auth":     r.UseBasicAuth,
 			}).Info("Modifying account")
 		}
 		if r.Debug {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":           r.Hostname,
 				"port":               r.Port,
 				"timeout":            r.Timeout,
@@ -939,28 +954,20 @@ func (r *Redfish) ModifyAccountByEndpoint(endpoint string, acd AccountCreateData
 				"flavor_string":      r.FlavorString,
 				"path":               endpoint,
 				"method":             "PATCH",
-				"additional_headers": nil,
-				"use_basic_auth":     false,
-				"payload":            payload,
+				"additional_headers": header,
+				"use_basic_auth":     r.UseBasicAuth,
+				"payload":            redactPayload(payload),
 			}).Debug("Modifying account")
 		}
-		response, err := r.httpRequest(endpoint, "PATCH", nil, strings.NewReader(payload), false)
+		response, err := r.httpRequest(ctx, endpoint, "PATCH", header, strings.NewReader(payload), false)
 		if err != nil {
 			return err
 		}
 
-		// some vendors like Supermicro imposes limits on fields like password and return HTTP 400 - Bad Request
-		if response.StatusCode == http.StatusBadRequest {
-			rerr, err := r.ProcessError(response)
-			if err != nil {
-				return fmt.Errorf("Operation failed, returned \"%s\" and no error information", response.Status)
-			}
-
-			errmsg := r.GetErrorMessage(rerr)
-			if errmsg != "" {
-				return fmt.Errorf("%s", errmsg)
-			}
-			return fmt.Errorf("Operation failed, returned \"%s\" and no error information", response.Status)
+		// some vendors like Supermicro imposes limits on fields like password and return HTTP 400 - Bad Request,
+		// the error object returned by the service processor is part of the HTTPError
+		if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+			return r.newHTTPError("PATCH", response, http.StatusOK, http.StatusNoContent)
 		}
 	}
 	return nil
@@ -0,0 +1,374 @@
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// GetBIOSData - get current BIOS attributes of a system
+func (r *Redfish) GetBIOSData(sd *SystemData) (*BIOSData, error) {
+	return r.GetBIOSDataContext(context.Background(), sd)
+}
+
+// GetBIOSDataContext - same as GetBIOSData but uses the supplied context for all HTTP requests
+func (r *Redfish) GetBIOSDataContext(ctx context.Context, sd *SystemData) (*BIOSData, error) {
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	if sd.BIOS == nil || sd.BIOS.ID == nil || *sd.BIOS.ID == "" {
+		return nil, r.newNotSupportedError("System does not provide a BIOS resource")
+	}
+
+	return r.getBIOSData(ctx, *sd.BIOS.ID)
+}
+
+func (r *Redfish) getBIOSData(ctx context.Context, endpoint string) (*BIOSData, error) {
+	var result BIOSData
+
+	response, err := r.getJSON(ctx, endpoint, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	result.SelfEndpoint = &endpoint
+	result.ETag = etagFromResult(response, result.ETag)
+	return &result, nil
+}
+
+// get endpoint of the resource for pending BIOS settings, empty if changes are applied to the BIOS resource itself
+func biosSettingsEndpoint(bios *BIOSData) string {
+	if bios.Settings == nil || bios.Settings.SettingsObject == nil || bios.Settings.SettingsObject.ID == nil {
+		return ""
+	}
+	return *bios.Settings.SettingsObject.ID
+}
+
+// GetBIOSPendingSettings - get the BIOS attributes that will be applied on the next reboot,
+// nil if the service processor doesn't provide a resource for pending settings
+func (r *Redfish) GetBIOSPendingSettings(bios *BIOSData) (*BIOSData, error) {
+	return r.GetBIOSPendingSettingsContext(context.Background(), bios)
+}
+
+// GetBIOSPendingSettingsContext - same as GetBIOSPendingSettings but uses the supplied context for all HTTP requests
+func (r *Redfish) GetBIOSPendingSettingsContext(ctx context.Context, bios *BIOSData) (*BIOSData, error) {
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	endpoint := biosSettingsEndpoint(bios)
+	if endpoint == "" {
+		return nil, nil
+	}
+
+	return r.getBIOSData(ctx, endpoint)
+}
+
+// GetBIOSPendingChanges - get attributes with a pending value different from the current value
+func (r *Redfish) GetBIOSPendingChanges(bios *BIOSData) (map[string]BIOSPendingValue, error) {
+	return r.GetBIOSPendingChangesContext(context.Background(), bios)
+}
+
+// GetBIOSPendingChangesContext - same as GetBIOSPendingChanges but uses the supplied context for all HTTP requests
+func (r *Redfish) GetBIOSPendingChangesContext(ctx context.Context, bios *BIOSData) (map[string]BIOSPendingValue, error) {
+	var result = make(map[string]BIOSPendingValue)
+
+	pending, err := r.GetBIOSPendingSettingsContext(ctx, bios)
+	if err != nil {
+		return result, err
+	}
+
+	if pending == nil {
+		return result, nil
+	}
+
+	// Note: Some vendors only report changed attributes, others report all attributes in the settings resource
+	for name, value := range pending.Attributes {
+		current := bios.Attributes[name]
+		if !reflect.DeepEqual(current, value) {
+			result[name] = BIOSPendingValue{
+				Current: current,
+				Pending: value,
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// GetBIOSAttributeRegistry - get the attribute registry describing the BIOS attributes
+func (r *Redfish) GetBIOSAttributeRegistry(bios *BIOSData) (*BIOSAttributeRegistry, error) {
+	return r.GetBIOSAttributeRegistryContext(context.Background(), bios)
+}
+
+// GetBIOSAttributeRegistryContext - same as GetBIOSAttributeRegistry but uses the supplied context for all HTTP requests
+func (r *Redfish) GetBIOSAttributeRegistryContext(ctx context.Context, bios *BIOSData) (*BIOSAttributeRegistry, error) {
+	var result BIOSAttributeRegistry
+	var candidates = make([]string, 0)
+	var others = make([]string, 0)
+
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	if bios.AttributeRegistry == nil || *bios.AttributeRegistry == "" {
+		return nil, r.newNotSupportedError("BIOS resource does not reference an attribute registry")
+	}
+	name := *bios.AttributeRegistry
+
+	if r.Registries == "" {
+		return nil, r.newNotSupportedError("Service processor does not provide registries")
+	}
+
+	members, err := r.GetCollectionMembersContext(ctx, r.Registries)
+	if err != nil {
+		return nil, err
+	}
+
+	// registry files are usually named after the registry, check them first to avoid fetching all registry files
+	for _, m := range members {
+		if strings.HasSuffix(strings.TrimRight(m, "/"), "/"+name) {
+			candidates = append(candidates, m)
+		} else {
+			others = append(others, m)
+		}
+	}
+	candidates = append(candidates, others...)
+
+	for _, m := range candidates {
+		var rf registryFileData
+
+		// a broken registry file must not prevent finding the attribute registry in the other files
+		_, err = r.getJSON(ctx, m, &rf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			r.logger().WithFields(LogFields{
+				"hostname":      r.Hostname,
+				"port":          r.Port,
+				"timeout":       r.Timeout,
+				"flavor":        r.Flavor,
+				"flavor_string": r.FlavorString,
+				"path":          m,
+				"error":         err.Error(),
+			}).Warning("Can't fetch registry file, skipping it")
+			continue
+		}
+
+		if (rf.ID == nil || *rf.ID != name) && (rf.Registry == nil || *rf.Registry != name) {
+			continue
+		}
+
+		uri := registryLocation(rf.Location)
+		if uri == "" {
+			return nil, fmt.Errorf("BUG: Registry file %s for %s has no location on the service processor", m, name)
+		}
+
+		_, err = r.getJSON(ctx, uri, &result)
+		if err != nil {
+			return nil, err
+		}
+		return &result, nil
+	}
+
+	return nil, fmt.Errorf("Attribute registry %s not found", name)
+}
+
+// pick location of the registry on the service processor, prefer english
+func registryLocation(locations []registryFileLocation) string {
+	var result string
+
+	for _, loc := range locations {
+		if loc.URI == nil || *loc.URI == "" {
+			continue
+		}
+
+		if loc.Language != nil && strings.ToLower(*loc.Language) == "en" {
+			return *loc.URI
+		}
+
+		if result == "" {
+			result = *loc.URI
+		}
+	}
+	return result
+}
+
+// Attribute - get definition of an attribute, nil if the attribute is not defined
+func (reg *BIOSAttributeRegistry) Attribute(name string) *BIOSAttributeDefinition {
+	for i := range reg.RegistryEntries.Attributes {
+		a := &reg.RegistryEntries.Attributes[i]
+		if a.AttributeName != nil && *a.AttributeName == name {
+			return a
+		}
+	}
+	return nil
+}
+
+// ValidateAttributes - check if attributes can be set to the new values according to the registry
+func (reg *BIOSAttributeRegistry) ValidateAttributes(attrs map[string]interface{}) error {
+	// check attributes in a fixed order to report the same error for the same attributes
+	var names = make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := attrs[name]
+		def := reg.Attribute(name)
+		if def == nil {
+			return fmt.Errorf("BIOS attribute %s is not defined in attribute registry", name)
+		}
+
+		if def.ReadOnly || def.Immutable {
+			return fmt.Errorf("BIOS attribute %s is read-only", name)
+		}
+
+		err := def.validate(value)
+		if err != nil {
+			return fmt.Errorf("Invalid value for BIOS attribute %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (def *BIOSAttributeDefinition) validate(value interface{}) error {
+	var attrType string
+
+	if def.Type != nil {
+		attrType = *def.Type
+	}
+
+	switch attrType {
+	case "Enumeration":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected string, got %T", value)
+		}
+
+		var allowed = make([]string, 0)
+		for _, v := range def.Value {
+			if v.ValueName == nil {
+				continue
+			}
+			if *v.ValueName == s {
+				return nil
+			}
+			allowed = append(allowed, *v.ValueName)
+		}
+		return fmt.Errorf("%s is not one of %s", s, strings.Join(allowed, ", "))
+
+	case "String", "Password":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected string, got %T", value)
+		}
+
+		if def.MinLength != nil && len(s) < *def.MinLength {
+			return fmt.Errorf("value is shorter than %d characters", *def.MinLength)
+		}
+		if def.MaxLength != nil && len(s) > *def.MaxLength {
+			return fmt.Errorf("value is longer than %d characters", *def.MaxLength)
+		}
+
+		if def.ValueExpression != nil && *def.ValueExpression != "" {
+			// the expression may use a syntax not supported by Go, skip the check in this case
+			re, err := regexp.Compile("^(?:" + *def.ValueExpression + ")$")
+			if err == nil && !re.MatchString(s) {
+				return fmt.Errorf("value does not match %s", *def.ValueExpression)
+			}
+		}
+
+	case "Integer":
+		i, ok := integerValue(value)
+		if !ok {
+			return fmt.Errorf("expected integer, got %T", value)
+		}
+
+		if def.LowerBound != nil && i < *def.LowerBound {
+			return fmt.Errorf("%d is less than %d", i, *def.LowerBound)
+		}
+		if def.UpperBound != nil && i > *def.UpperBound {
+			return fmt.Errorf("%d is greater than %d", i, *def.UpperBound)
+		}
+
+	case "Boolean":
+		_, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected boolean, got %T", value)
+		}
+	}
+
+	return nil
+}
+
+func integerValue(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		return int64(v), true
+	}
+	return 0, false
+}
+
+// SetBIOSAttributes - stage new values of BIOS attributes, they will be applied on the next reboot of the system.
+// If registry is not nil the new values are validated before they are sent to the service processor
+func (r *Redfish) SetBIOSAttributes(bios *BIOSData, registry *BIOSAttributeRegistry, attrs map[string]interface{}) error {
+	return r.SetBIOSAttributesContext(context.Background(), bios, registry, attrs)
+}
+
+// SetBIOSAttributesContext - same as SetBIOSAttributes but uses the supplied context for all HTTP requests
+func (r *Redfish) SetBIOSAttributesContext(ctx context.Context, bios *BIOSData, registry *BIOSAttributeRegistry, attrs map[string]interface{}) error {
+	var header *map[string]string
+
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	if registry != nil {
+		err := registry.ValidateAttributes(attrs)
+		if err != nil {
+			return err
+		}
+	}
+
+	// changes are staged in the settings resource, if there is none they are sent to the BIOS resource itself
+	endpoint := biosSettingsEndpoint(bios)
+	if endpoint == "" {
+		if bios.SelfEndpoint == nil || *bios.SelfEndpoint == "" {
+			return fmt.Errorf("BUG: SelfEndpoint not set or empty in BIOS data")
+		}
+		endpoint = *bios.SelfEndpoint
+
+		if bios.ETag != nil {
+			header = ifMatchHeader(*bios.ETag)
+		}
+	}
+
+	_, err := r.sendJSON(ctx, "PATCH", endpoint, header, biosAttributesPayload{Attributes: attrs})
+	return err
+}
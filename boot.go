@@ -0,0 +1,254 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// values of BootSourceOverrideEnabled defined by the standard, used if the system doesn't report allowable values
+var defaultBootSourceOverrideEnabledValues = []string{"Disabled", "Once", "Continuous"}
+
+// map value to the allowed value (compared case insensitive), an empty list of allowed values accepts every value
+func allowableValue(property string, value string, allowed []string) (string, error) {
+	if len(allowed) == 0 {
+		return value, nil
+	}
+
+	allowedValues := make(map[string]string)
+	for _, a := range allowed {
+		_a := strings.ToLower(a)
+		allowedValues[_a] = a
+	}
+
+	_value := strings.TrimSpace(strings.ToLower(value))
+	result, found := allowedValues[_value]
+	if found {
+		return result, nil
+	}
+
+	return "", fmt.Errorf("Requested value %s for %s is not supported by this system, supported values are: %s", value, property, strings.Join(allowed, ", "))
+}
+
+func (r *Redfish) patchSystemBoot(ctx context.Context, sd *SystemData, endpoint string, payload interface{}) error {
+	var header *map[string]string
+
+	// the entity tag only applies to the system resource, not to the settings resource. It's the entity tag of the
+	// whole system, so a stale SystemData results in HTTP 412 (Precondition Failed)
+	if sd.SelfEndpoint != nil && endpoint == *sd.SelfEndpoint && sd.ETag != nil {
+		header = ifMatchHeader(*sd.ETag)
+	}
+
+	_, err := r.sendJSON(ctx, "PATCH", endpoint, header, payload)
+	return err
+}
+
+// SetSystemBootOverride - set boot source override of the system
+//
+// Note: The entity tag of sd is sent as If-Match and covers the whole system resource, it changes with e.g. the power state.
+// Read sd by GetSystemData right before the change, otherwise the service processor may reject it with HTTP 412.
+func (r *Redfish) SetSystemBootOverride(sd *SystemData, override BootOverride) error {
+	return r.SetSystemBootOverrideContext(context.Background(), sd, override)
+}
+
+// SetSystemBootOverrideContext - same as SetSystemBootOverride but uses the supplied context for all HTTP requests
+func (r *Redfish) SetSystemBootOverrideContext(ctx context.Context, sd *SystemData, override BootOverride) error {
+	var payload bootOverridePayload
+	var err error
+
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	if sd.Boot == nil {
+		return r.newNotSupportedError("System does not support boot source override")
+	}
+
+	if sd.SelfEndpoint == nil || *sd.SelfEndpoint == "" {
+		return errors.New("BUG: SelfEndpoint not set or empty in system data")
+	}
+
+	if override.Target == "" {
+		return errors.New("Boot source override target is empty")
+	}
+
+	payload.Boot.BootSourceOverrideTarget, err = allowableValue("BootSourceOverrideTarget", override.Target, sd.Boot.BootSourceOverrideTargetValues)
+	if err != nil {
+		return err
+	}
+
+	enabled := "Once"
+	if override.Persistent {
+		enabled = "Continuous"
+	}
+
+	enabledValues := sd.Boot.BootSourceOverrideEnabledValues
+	if len(enabledValues) == 0 {
+		enabledValues = defaultBootSourceOverrideEnabledValues
+	}
+
+	payload.Boot.BootSourceOverrideEnabled, err = allowableValue("BootSourceOverrideEnabled", enabled, enabledValues)
+	if err != nil {
+		return err
+	}
+
+	if override.Mode != "" {
+		payload.Boot.BootSourceOverrideMode, err = allowableValue("BootSourceOverrideMode", override.Mode, sd.Boot.BootSourceOverrideModeValues)
+		if err != nil {
+			return err
+		}
+	}
+
+	if payload.Boot.BootSourceOverrideTarget == "UefiTarget" {
+		if override.UefiTarget == "" {
+			return errors.New("Boot source override target UefiTarget requires the UEFI device path")
+		}
+		payload.Boot.UefiTargetBootSourceOverride = override.UefiTarget
+	}
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":      r.Hostname,
+			"port":          r.Port,
+			"timeout":       r.Timeout,
+			"flavor":        r.Flavor,
+			"flavor_string": r.FlavorString,
+			"path":          *sd.SelfEndpoint,
+			"target":        payload.Boot.BootSourceOverrideTarget,
+			"enabled":       payload.Boot.BootSourceOverrideEnabled,
+			"mode":          payload.Boot.BootSourceOverrideMode,
+		}).Info("Setting boot source override")
+	}
+
+	return r.patchSystemBoot(ctx, sd, *sd.SelfEndpoint, payload)
+}
+
+// ClearSystemBootOverride - disable boot source override of the system, sd should be read right before
+// (see SetSystemBootOverride)
+func (r *Redfish) ClearSystemBootOverride(sd *SystemData) error {
+	return r.ClearSystemBootOverrideContext(context.Background(), sd)
+}
+
+// ClearSystemBootOverrideContext - same as ClearSystemBootOverride but uses the supplied context for all HTTP requests
+func (r *Redfish) ClearSystemBootOverrideContext(ctx context.Context, sd *SystemData) error {
+	var payload bootOverridePayload
+	var err error
+
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	if sd.Boot == nil {
+		return r.newNotSupportedError("System does not support boot source override")
+	}
+
+	if sd.SelfEndpoint == nil || *sd.SelfEndpoint == "" {
+		return errors.New("BUG: SelfEndpoint not set or empty in system data")
+	}
+
+	enabledValues := sd.Boot.BootSourceOverrideEnabledValues
+	if len(enabledValues) == 0 {
+		enabledValues = defaultBootSourceOverrideEnabledValues
+	}
+
+	payload.Boot.BootSourceOverrideEnabled, err = allowableValue("BootSourceOverrideEnabled", "Disabled", enabledValues)
+	if err != nil {
+		return err
+	}
+
+	return r.patchSystemBoot(ctx, sd, *sd.SelfEndpoint, payload)
+}
+
+// SetSystemBootOrder - set boot order of the system, order must contain every reference of the current boot order
+// (BootOptionReference of the boot options) exactly once. sd should be read right before (see SetSystemBootOverride).
+func (r *Redfish) SetSystemBootOrder(sd *SystemData, order []string) error {
+	return r.SetSystemBootOrderContext(context.Background(), sd, order)
+}
+
+// SetSystemBootOrderContext - same as SetSystemBootOrder but uses the supplied context for all HTTP requests
+func (r *Redfish) SetSystemBootOrderContext(ctx context.Context, sd *SystemData, order []string) error {
+	var known = make(map[string]bool)
+	var seen = make(map[string]bool)
+	var payload bootOrderPayload
+
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	if sd.Boot == nil || len(sd.Boot.BootOrder) == 0 {
+		return r.newNotSupportedError("System does not report a boot order")
+	}
+
+	if sd.SelfEndpoint == nil || *sd.SelfEndpoint == "" {
+		return errors.New("BUG: SelfEndpoint not set or empty in system data")
+	}
+
+	// a partial boot order would drop boot options on some service processors, null would remove all of them
+	if len(order) != len(sd.Boot.BootOrder) {
+		return fmt.Errorf("Boot order must contain all %d boot options of this system, got %d", len(sd.Boot.BootOrder), len(order))
+	}
+
+	for _, ref := range sd.Boot.BootOrder {
+		known[ref] = true
+	}
+
+	for _, ref := range order {
+		if !known[ref] {
+			return fmt.Errorf("Boot option %s is not part of the boot order of this system", ref)
+		}
+		if seen[ref] {
+			return fmt.Errorf("Boot option %s is listed more than once", ref)
+		}
+		seen[ref] = true
+	}
+
+	payload.Boot.BootOrder = order
+
+	// some vendors (e.g. DELL) only accept changes of the boot order in the settings resource
+	endpoint := *sd.SelfEndpoint
+	if sd.Settings != nil && sd.Settings.SettingsObject != nil && sd.Settings.SettingsObject.ID != nil {
+		endpoint = *sd.Settings.SettingsObject.ID
+	}
+
+	return r.patchSystemBoot(ctx, sd, endpoint, payload)
+}
+
+// GetSystemBootOptions - get boot options of the system
+func (r *Redfish) GetSystemBootOptions(sd *SystemData) ([]*BootOptionData, error) {
+	return r.GetSystemBootOptionsContext(context.Background(), sd)
+}
+
+// GetSystemBootOptionsContext - same as GetSystemBootOptions but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemBootOptionsContext(ctx context.Context, sd *SystemData) ([]*BootOptionData, error) {
+	var result = make([]*BootOptionData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	if sd.Boot == nil || sd.Boot.BootOptions == nil || sd.Boot.BootOptions.ID == nil {
+		return result, r.newNotSupportedError("System does not provide boot options")
+	}
+
+	members, err := r.getCollectionContent(ctx, *sd.Boot.BootOptions.ID)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d BootOptionData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
@@ -1,15 +1,15 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 	"strings"
 )
 
-func (r *Redfish) getImportCertTargetHP(mgr *ManagerData) (string, error) {
+func (r *Redfish) getImportCertTargetHP(ctx context.Context, mgr *ManagerData) (string, error) {
 	var certTarget string
 	var oemHp ManagerDataOemHp
 	var secsvc string
@@ -30,7 +30,7 @@ func (r *Redfish) getImportCertTargetHP(mgr *ManagerData) (string, error) {
 	secsvc = *oemHp.Hp.Links.SecurityService.ID
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -39,10 +39,10 @@ func (r *Redfish) getImportCertTargetHP(mgr *ManagerData) (string, error) {
 			"path":               secsvc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to security service")
 	}
-	response, err := r.httpRequest(secsvc, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, secsvc, "GET", nil, nil, false)
 	if err != nil {
 		return certTarget, err
 	}
@@ -50,7 +50,7 @@ func (r *Redfish) getImportCertTargetHP(mgr *ManagerData) (string, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return certTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return certTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &oemSSvc)
@@ -64,7 +64,7 @@ func (r *Redfish) getImportCertTargetHP(mgr *ManagerData) (string, error) {
 
 	httpscertloc = *oemSSvc.Links.HTTPSCert.ID
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -73,10 +73,10 @@ func (r *Redfish) getImportCertTargetHP(mgr *ManagerData) (string, error) {
 			"path":               httpscertloc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path for SSL certificate import")
 	}
-	response, err = r.httpRequest(httpscertloc, "GET", nil, nil, false)
+	response, err = r.httpRequest(ctx, httpscertloc, "GET", nil, nil, false)
 	if err != nil {
 		return certTarget, err
 	}
@@ -84,7 +84,7 @@ func (r *Redfish) getImportCertTargetHP(mgr *ManagerData) (string, error) {
 	raw = response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return certTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return certTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &httpscert)
@@ -100,7 +100,7 @@ func (r *Redfish) getImportCertTargetHP(mgr *ManagerData) (string, error) {
 	return certTarget, nil
 }
 
-func (r *Redfish) getImportCertTargetHPE(mgr *ManagerData) (string, error) {
+func (r *Redfish) getImportCertTargetHPE(ctx context.Context, mgr *ManagerData) (string, error) {
 	var certTarget string
 	var oemHpe ManagerDataOemHpe
 	var secsvc string
@@ -121,7 +121,7 @@ func (r *Redfish) getImportCertTargetHPE(mgr *ManagerData) (string, error) {
 	secsvc = *oemHpe.Hpe.Links.SecurityService.ID
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -130,10 +130,10 @@ func (r *Redfish) getImportCertTargetHPE(mgr *ManagerData) (string, error) {
 			"path":               secsvc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to security service")
 	}
-	response, err := r.httpRequest(secsvc, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, secsvc, "GET", nil, nil, false)
 	if err != nil {
 		return certTarget, err
 	}
@@ -141,7 +141,7 @@ func (r *Redfish) getImportCertTargetHPE(mgr *ManagerData) (string, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return certTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return certTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &oemSSvc)
@@ -155,7 +155,7 @@ func (r *Redfish) getImportCertTargetHPE(mgr *ManagerData) (string, error) {
 
 	httpscertloc = *oemSSvc.Links.HTTPSCert.ID
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -164,10 +164,10 @@ func (r *Redfish) getImportCertTargetHPE(mgr *ManagerData) (string, error) {
 			"path":               httpscertloc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path for SSL certificate import")
 	}
-	response, err = r.httpRequest(httpscertloc, "GET", nil, nil, false)
+	response, err = r.httpRequest(ctx, httpscertloc, "GET", nil, nil, false)
 	if err != nil {
 		return certTarget, err
 	}
@@ -175,7 +175,7 @@ func (r *Redfish) getImportCertTargetHPE(mgr *ManagerData) (string, error) {
 	raw = response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return certTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return certTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &httpscert)
@@ -191,7 +191,7 @@ func (r *Redfish) getImportCertTargetHPE(mgr *ManagerData) (string, error) {
 	return certTarget, nil
 }
 
-func (r *Redfish) getImportCertTargetHuawei(mgr *ManagerData) (string, error) {
+func (r *Redfish) getImportCertTargetHuawei(ctx context.Context, mgr *ManagerData) (string, error) {
 	var certTarget string
 	var oemHuawei ManagerDataOemHuawei
 	var secsvc string
@@ -212,7 +212,7 @@ func (r *Redfish) getImportCertTargetHuawei(mgr *ManagerData) (string, error) {
 	secsvc = *oemHuawei.Huawei.SecurityService.ID
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -221,10 +221,10 @@ func (r *Redfish) getImportCertTargetHuawei(mgr *ManagerData) (string, error) {
 			"path":               secsvc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to security service")
 	}
-	response, err := r.httpRequest(secsvc, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, secsvc, "GET", nil, nil, false)
 	if err != nil {
 		return certTarget, err
 	}
@@ -232,7 +232,7 @@ func (r *Redfish) getImportCertTargetHuawei(mgr *ManagerData) (string, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return certTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return certTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &oemSSvc)
@@ -247,7 +247,7 @@ func (r *Redfish) getImportCertTargetHuawei(mgr *ManagerData) (string, error) {
 	httpscertloc = *oemSSvc.Links.HTTPSCert.ID
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -256,10 +256,10 @@ func (r *Redfish) getImportCertTargetHuawei(mgr *ManagerData) (string, error) {
 			"path":               httpscertloc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path for SSL certificate import")
 	}
-	response, err = r.httpRequest(httpscertloc, "GET", nil, nil, false)
+	response, err = r.httpRequest(ctx, httpscertloc, "GET", nil, nil, false)
 	if err != nil {
 		return certTarget, err
 	}
@@ -267,7 +267,7 @@ func (r *Redfish) getImportCertTargetHuawei(mgr *ManagerData) (string, error) {
 	raw = response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return certTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return certTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &httpscert)
@@ -283,35 +283,48 @@ func (r *Redfish) getImportCertTargetHuawei(mgr *ManagerData) (string, error) {
 	return certTarget, nil
 }
 
+func (r *Redfish) makeCertificateImportPayload(cert string) (string, error) {
+	raw, err := json.Marshal(certificateImportPayload{Certificate: cert})
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
 // ImportCertificate - import certificate
 func (r *Redfish) ImportCertificate(cert string) error {
+	return r.ImportCertificateContext(context.Background(), cert)
+}
+
+// ImportCertificateContext - same as ImportCertificate but uses the supplied context for all HTTP requests
+func (r *Redfish) ImportCertificateContext(ctx context.Context, cert string) error {
 	var certtarget string
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
 	}
 
 	// set vendor flavor
-	err := r.GetVendorFlavor()
+	err := r.GetVendorFlavorContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	// get list of Manager endpoint
-	mgrList, err := r.GetManagers()
+	mgrList, err := r.GetManagersContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	// pick the first entry
-	mgr0, err := r.GetManagerData(mgrList[0])
+	mgr0, err := r.GetManagerDataContext(ctx, mgrList[0])
 	if err != nil {
 		return err
 	}
 
 	// get endpoint SecurityService from Managers
 	if r.Flavor == RedfishHP {
-		certtarget, err = r.getImportCertTargetHP(mgr0)
+		certtarget, err = r.getImportCertTargetHP(ctx, mgr0)
 		if err != nil {
 			return err
 		}
@@ -319,7 +332,7 @@ func (r *Redfish) ImportCertificate(cert string) error {
 		// HP/HPE service processors (iLO) will reboot automatically
 		// if the certificate has been imported successfully
 	} else if r.Flavor == RedfishHPE {
-		certtarget, err = r.getImportCertTargetHPE(mgr0)
+		certtarget, err = r.getImportCertTargetHPE(ctx, mgr0)
 		if err != nil {
 			return err
 		}
@@ -327,34 +340,35 @@ func (r *Redfish) ImportCertificate(cert string) error {
 		// HP/HPE service processors (iLO) will reboot automatically
 		// if the certificate has been imported successfully
 	} else if r.Flavor == RedfishHuawei {
-		certtarget, err = r.getImportCertTargetHuawei(mgr0)
+		certtarget, err = r.getImportCertTargetHuawei(ctx, mgr0)
 		if err != nil {
 			return err
 		}
 
 		// Reboot service processor to activate new certificate
-		err = r.ResetSP()
+		err = r.ResetSPContext(ctx)
 		if err != nil {
 			return err
 		}
 	} else if r.Flavor == RedfishInspur {
-		return errors.New("Inspur management boards do not support certificate import")
+		return r.newNotSupportedError("Inspur management boards do not support certificate import")
 	} else if r.Flavor == RedfishSuperMicro {
-		return errors.New("SuperMicro management boards do not support certificate import")
+		return r.newNotSupportedError("SuperMicro management boards do not support certificate import")
 	} else {
-		return errors.New("Unable to get vendor for management board. If this vendor supports certificate import please file a feature request")
+		return r.newNotSupportedError("Unable to get vendor for management board. If this vendor supports certificate import please file a feature request")
 	}
 
 	if certtarget == "" {
 		return errors.New("BUG: Target for certificate import is not known")
 	}
 
-	// escape new lines
-	rawcert := strings.Replace(cert, "\n", "\\n", -1)
-	certPayload := fmt.Sprintf("{ \"Certificate\": \"%s\" }", rawcert)
+	certPayload, err := r.makeCertificateImportPayload(cert)
+	if err != nil {
+		return err
+	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -363,11 +377,11 @@ func (r *Redfish) ImportCertificate(cert string) error {
 			"path":               certtarget,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Importing SSL certificate")
 	}
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -376,18 +390,18 @@ func (r *Redfish) ImportCertificate(cert string) error {
 			"path":               certtarget,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
-			"payload":            certPayload,
+			"use_basic_auth":     r.UseBasicAuth,
+			"payload":            redactPayload(certPayload),
 		}).Debug("Importing SSL certificate")
 	}
-	response, err := r.httpRequest(certtarget, "POST", nil, strings.NewReader(certPayload), false)
+	response, err := r.httpRequest(ctx, certtarget, "POST", nil, strings.NewReader(certPayload), false)
 	if err != nil {
 		return err
 	}
 	// XXX: do we need to look at the content returned by HTTP POST ?
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP POST to %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return r.newHTTPError("POST", response, http.StatusOK)
 	}
 
 	return nil
@@ -1,71 +1,52 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 )
 
 // GetChassis - get array of chassis and their endpoints
 func (r *Redfish) GetChassis() ([]string, error) {
-	var chassis OData
-	var result = make([]string, 0)
-
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return result, errors.New("No authentication token found, is the session setup correctly?")
-	}
-
-	if r.Verbose {
-		log.WithFields(log.Fields{
-			"hostname":           r.Hostname,
-			"port":               r.Port,
-			"timeout":            r.Timeout,
-			"flavor":             r.Flavor,
-			"flavor_string":      r.FlavorString,
-			"path":               r.Chassis,
-			"method":             "GET",
-			"additional_headers": nil,
-			"use_basic_auth":     false,
-		}).Info("Requesting installed chassis components")
-	}
-	response, err := r.httpRequest(r.Chassis, "GET", nil, nil, false)
-	if err != nil {
-		return result, err
-	}
+	return r.GetChassisContext(context.Background())
+}
 
-	raw := response.Content
+// GetChassisContext - same as GetChassis but uses the supplied context for all HTTP requests
+func (r *Redfish) GetChassisContext(ctx context.Context) ([]string, error) {
+	var result = make([]string, 0)
 
-	if response.StatusCode != http.StatusOK {
-		return result, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
 	}
 
-	err = json.Unmarshal(raw, &chassis)
+	result, err := r.GetCollectionMembersContext(ctx, r.Chassis)
 	if err != nil {
 		return result, err
 	}
 
-	if len(chassis.Members) == 0 {
+	if len(result) == 0 {
 		return result, errors.New("BUG: Array of chassis endpoints is empty")
 	}
-
-	for _, endpoint := range chassis.Members {
-		result = append(result, *endpoint.ID)
-	}
 	return result, nil
 }
 
 // GetChassisData - get chassis data for a particular chassis
 func (r *Redfish) GetChassisData(chassisEndpoint string) (*ChassisData, error) {
+	return r.GetChassisDataContext(context.Background(), chassisEndpoint)
+}
+
+// GetChassisDataContext - same as GetChassisData but uses the supplied context for all HTTP requests
+func (r *Redfish) GetChassisDataContext(ctx context.Context, chassisEndpoint string) (*ChassisData, error) {
 	var result ChassisData
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return nil, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -74,10 +55,10 @@ func (r *Redfish) GetChassisData(chassisEndpoint string) (*ChassisData, error) {
 			"path":               chassisEndpoint,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting chassis information")
 	}
-	response, err := r.httpRequest(chassisEndpoint, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, chassisEndpoint, "GET", nil, nil, false)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +66,7 @@ func (r *Redfish) GetChassisData(chassisEndpoint string) (*ChassisData, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return nil, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &result)
@@ -94,27 +75,57 @@ func (r *Redfish) GetChassisData(chassisEndpoint string) (*ChassisData, error) {
 	}
 
 	result.SelfEndpoint = &chassisEndpoint
+	result.ETag = etagFromResult(response, result.ETag)
 	return &result, nil
 }
 
-// MapChassisByID - Map chassis by ID
-func (r *Redfish) MapChassisByID() (map[string]*ChassisData, error) {
-	var result = make(map[string]*ChassisData)
+// get data of all chassis, by a single request if the service processor supports $expand
+func (r *Redfish) getAllChassisData(ctx context.Context) ([]*ChassisData, error) {
+	var result = make([]*ChassisData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
 
-	chasl, err := r.GetChassis()
+	members, err := r.getCollectionContent(ctx, r.Chassis)
 	if err != nil {
-		return result, nil
+		return result, err
 	}
 
-	for _, chas := range chasl {
-		s, err := r.GetChassisData(chas)
+	for i := range members {
+		var d ChassisData
+
+		err = json.Unmarshal(members[i].content, &d)
 		if err != nil {
 			return result, err
 		}
 
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// MapChassisByID - Map chassis by ID
+func (r *Redfish) MapChassisByID() (map[string]*ChassisData, error) {
+	return r.MapChassisByIDContext(context.Background())
+}
+
+// MapChassisByIDContext - same as MapChassisByID but uses the supplied context for all HTTP requests
+func (r *Redfish) MapChassisByIDContext(ctx context.Context) (map[string]*ChassisData, error) {
+	var result = make(map[string]*ChassisData)
+
+	dl, err := r.getAllChassisData(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, s := range dl {
 		// should NEVER happen
 		if s.ID == nil {
-			return result, fmt.Errorf("BUG: No Id found for Chassis at %s", chas)
+			return result, fmt.Errorf("BUG: No Id found for Chassis at %s", *s.SelfEndpoint)
 		}
 
 		result[*s.ID] = s
@@ -125,14 +136,19 @@ func (r *Redfish) MapChassisByID() (map[string]*ChassisData, error) {
 
 // GetPowerData - get power data from endpoint
 func (r *Redfish) GetPowerData(powerEndpoint string) (*PowerData, error) {
+	return r.GetPowerDataContext(context.Background(), powerEndpoint)
+}
+
+// GetPowerDataContext - same as GetPowerData but uses the supplied context for all HTTP requests
+func (r *Redfish) GetPowerDataContext(ctx context.Context, powerEndpoint string) (*PowerData, error) {
 	var result PowerData
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return nil, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -141,16 +157,16 @@ func (r *Redfish) GetPowerData(powerEndpoint string) (*PowerData, error) {
 			"path":               powerEndpoint,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting power information")
 	}
-	response, err := r.httpRequest(powerEndpoint, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, powerEndpoint, "GET", nil, nil, false)
 	if err != nil {
 		return nil, err
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return nil, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(response.Content, &result)
@@ -164,14 +180,19 @@ func (r *Redfish) GetPowerData(powerEndpoint string) (*PowerData, error) {
 
 // GetThermalData - get thermal data from endpoint
 func (r *Redfish) GetThermalData(thermalEndpoint string) (*ThermalData, error) {
+	return r.GetThermalDataContext(context.Background(), thermalEndpoint)
+}
+
+// GetThermalDataContext - same as GetThermalData but uses the supplied context for all HTTP requests
+func (r *Redfish) GetThermalDataContext(ctx context.Context, thermalEndpoint string) (*ThermalData, error) {
 	var result ThermalData
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return nil, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -180,16 +201,16 @@ func (r *Redfish) GetThermalData(thermalEndpoint string) (*ThermalData, error) {
 			"path":               thermalEndpoint,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting thermal information")
 	}
-	response, err := r.httpRequest(thermalEndpoint, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, thermalEndpoint, "GET", nil, nil, false)
 	if err != nil {
 		return nil, err
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return nil, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(response.Content, &result)
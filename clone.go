@@ -16,6 +16,9 @@ func (r *Redfish) Clone() *Redfish {
 		cpy.SessionLocation = nil
 		cpy.Timeout = r.Timeout
 		cpy.InsecureSSL = r.InsecureSSL
+		cpy.CACertificates = r.CACertificates
+		cpy.ClientCertificates = r.ClientCertificates
+		cpy.PinStore = r.PinStore
 		cpy.Debug = r.Debug
 		cpy.Verbose = r.Verbose
 		cpy.RawBaseContent = r.RawBaseContent
@@ -23,11 +26,23 @@ func (r *Redfish) Clone() *Redfish {
 		cpy.Chassis = r.Chassis
 		cpy.Managers = r.Managers
 		cpy.SessionService = r.SessionService
+		cpy.Registries = r.Registries
 		cpy.Sessions = r.Sessions
 		cpy.Systems = r.Systems
 		cpy.Flavor = r.Flavor
 		cpy.FlavorString = r.FlavorString
+		cpy.ProtocolFeatures = r.ProtocolFeatures
+		cpy.HTTPClient = r.HTTPClient
+		cpy.Transport = r.Transport
+		cpy.RetryPolicy = r.RetryPolicy
+		cpy.SessionTimeout = r.sessionTimeout()
+		cpy.UseBasicAuth = r.UseBasicAuth
+		cpy.DisableReLogin = r.DisableReLogin
+		cpy.MaxConcurrentFetches = r.MaxConcurrentFetches
+		cpy.MaxConcurrentRequests = r.MaxConcurrentRequests
+		cpy.Logger = r.Logger
 		cpy.initialised = r.initialised
+		cpy.serviceRootQuirks = r.serviceRootQuirks
 
 		if r.AuthToken != nil {
 			*a = *r.AuthToken
@@ -0,0 +1,276 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// CollectionIterator - iterate over the members of a resource collection, following Members@odata.nextLink
+// if the service processor splits the collection into several pages
+type CollectionIterator struct {
+	redfish *Redfish
+	ctx     context.Context
+
+	// next page to fetch, empty if the last page has been fetched
+	next    string
+	visited map[string]bool
+	page    []OData
+	index   int
+	current string
+	total   int
+	err     error
+}
+
+// NewCollectionIterator - create iterator for the collection at endpoint, pages are fetched on demand by Next
+func (r *Redfish) NewCollectionIterator(endpoint string) *CollectionIterator {
+	return r.NewCollectionIteratorContext(context.Background(), endpoint)
+}
+
+// NewCollectionIteratorContext - same as NewCollectionIterator but uses the supplied context for all HTTP requests
+func (r *Redfish) NewCollectionIteratorContext(ctx context.Context, endpoint string) *CollectionIterator {
+	return &CollectionIterator{
+		redfish: r,
+		ctx:     ctx,
+		next:    endpoint,
+		visited: make(map[string]bool),
+	}
+}
+
+// Next - advance to the next member, returns false if there are no more members or an error occured
+func (it *CollectionIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.index >= len(it.page) {
+		if it.next == "" {
+			return false
+		}
+
+		it.err = it.fetchPage()
+		if it.err != nil {
+			return false
+		}
+	}
+
+	member := it.page[it.index]
+	it.index++
+
+	if member.ID == nil || *member.ID == "" {
+		it.err = fmt.Errorf("BUG: Collection member without @odata.id found")
+		return false
+	}
+
+	it.current = *member.ID
+	return true
+}
+
+// Member - endpoint of the current member
+func (it *CollectionIterator) Member() string {
+	return it.current
+}
+
+// Total - total number of members as reported by Members@odata.count of the first page,
+// 0 if the collection has not been fetched yet or the service processor doesn't report it
+func (it *CollectionIterator) Total() int {
+	return it.total
+}
+
+// Err - error that stopped the iteration, nil if all members have been processed
+func (it *CollectionIterator) Err() error {
+	return it.err
+}
+
+func (it *CollectionIterator) fetchPage() error {
+	var collection OData
+	var r = it.redfish
+
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	endpoint := it.next
+	if it.visited[endpoint] {
+		return fmt.Errorf("BUG: Members@odata.nextLink of collection points to already fetched page %s", endpoint)
+	}
+	it.visited[endpoint] = true
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":           r.Hostname,
+			"port":               r.Port,
+			"timeout":            r.Timeout,
+			"flavor":             r.Flavor,
+			"flavor_string":      r.FlavorString,
+			"path":               endpoint,
+			"method":             "GET",
+			"additional_headers": nil,
+			"use_basic_auth":     r.UseBasicAuth,
+			"page":               len(it.visited),
+		}).Info("Requesting collection members")
+	}
+	response, err := r.httpRequest(it.ctx, endpoint, "GET", nil, nil, false)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return r.newHTTPError("GET", response, http.StatusOK)
+	}
+
+	err = json.Unmarshal(response.Content, &collection)
+	if err != nil {
+		return err
+	}
+
+	// only the first page is guaranteed to report the number of members
+	if len(it.visited) == 1 {
+		it.total = collection.MembersCount
+	}
+
+	it.page = collection.Members
+	it.index = 0
+	it.next = ""
+	if collection.NextLink != nil {
+		it.next = *collection.NextLink
+	}
+
+	return nil
+}
+
+// GetCollectionMembers - get endpoints of all members of a collection
+func (r *Redfish) GetCollectionMembers(endpoint string) ([]string, error) {
+	return r.GetCollectionMembersContext(context.Background(), endpoint)
+}
+
+// GetCollectionMembersContext - same as GetCollectionMembers but uses the supplied context for all HTTP requests
+func (r *Redfish) GetCollectionMembersContext(ctx context.Context, endpoint string) ([]string, error) {
+	var result = make([]string, 0)
+
+	it := r.NewCollectionIteratorContext(ctx, endpoint)
+	for it.Next() {
+		result = append(result, it.Member())
+	}
+
+	if it.Err() != nil {
+		return result, it.Err()
+	}
+	return result, nil
+}
+
+// collectionMember - content of a collection member as returned by getCollectionContent
+type collectionMember struct {
+	endpoint string
+	content  json.RawMessage
+	// entity tag as reported by the ETag header, nil for expanded members
+	etag *string
+}
+
+// entity tag of the member, the ETag header takes precedence over @odata.etag from the content
+func (m collectionMember) entityTag(etag *string) *string {
+	if m.etag != nil {
+		return m.etag
+	}
+	return etagFromResult(HTTPResult{}, etag)
+}
+
+// getCollectionContent - get content of all members of a collection, by a single request (per page) if the service
+// processor supports $expand. Otherwise the members are fetched one by one (or MaxConcurrentFetches in parallel).
+func (r *Redfish) getCollectionContent(ctx context.Context, endpoint string) ([]collectionMember, error) {
+	var result = make([]collectionMember, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	members, err := r.expandCollection(ctx, endpoint)
+	if err != nil {
+		return result, err
+	}
+
+	if members != nil {
+		for _, raw := range members {
+			var odata OData
+
+			memberEndpoint, err := decodeExpandedMember(raw, &odata)
+			if err != nil {
+				return result, err
+			}
+
+			result = append(result, collectionMember{
+				endpoint: memberEndpoint,
+				content:  raw,
+			})
+		}
+		return result, nil
+	}
+
+	el, err := r.GetCollectionMembersContext(ctx, endpoint)
+	if err != nil {
+		return result, err
+	}
+
+	data := make([]collectionMember, len(el))
+	err = r.fetchMembers(ctx, len(el), func(ctx context.Context, i int) error {
+		var raw json.RawMessage
+
+		response, err := r.getJSON(ctx, el[i], &raw)
+		if err != nil {
+			return err
+		}
+
+		data[i] = collectionMember{
+			endpoint: el[i],
+			content:  raw,
+			etag:     etagFromResult(response, nil),
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	return data, nil
+}
+
+// getLinkedContent - get content of the resources referenced by a list of links (e.g. Drives of a Storage resource),
+// MaxConcurrentFetches resources are fetched in parallel
+func (r *Redfish) getLinkedContent(ctx context.Context, links []OData) ([]collectionMember, error) {
+	var result = make([]collectionMember, 0)
+	var el = make([]string, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	for _, l := range links {
+		if l.ID == nil || *l.ID == "" {
+			return result, fmt.Errorf("BUG: Link without @odata.id found")
+		}
+		el = append(el, *l.ID)
+	}
+
+	data := make([]collectionMember, len(el))
+	err := r.fetchMembers(ctx, len(el), func(ctx context.Context, i int) error {
+		var raw json.RawMessage
+
+		response, err := r.getJSON(ctx, el[i], &raw)
+		if err != nil {
+			return err
+		}
+
+		data[i] = collectionMember{
+			endpoint: el[i],
+			content:  raw,
+			etag:     etagFromResult(response, nil),
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	return data, nil
+}
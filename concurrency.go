@@ -0,0 +1,100 @@
+package redfish
+
+import (
+	"context"
+	"sync"
+)
+
+// getRequestSemaphore - get semaphore limiting the number of concurrent requests of this Redfish object,
+// nil if the number of concurrent requests is not limited. The semaphore is created on first use.
+func (r *Redfish) getRequestSemaphore() chan struct{} {
+	r.requestSemaphoreLock.Lock()
+	defer r.requestSemaphoreLock.Unlock()
+
+	if r.MaxConcurrentRequests <= 0 {
+		return nil
+	}
+
+	if r.requestSemaphore == nil || cap(r.requestSemaphore) != r.MaxConcurrentRequests {
+		r.requestSemaphore = make(chan struct{}, r.MaxConcurrentRequests)
+	}
+	return r.requestSemaphore
+}
+
+// acquireRequestSlot - wait until a request can be sent, the returned function must be called to release the slot
+func (r *Redfish) acquireRequestSlot(ctx context.Context) (func(), error) {
+	sem := r.getRequestSemaphore()
+	if sem == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetchMembers - call fetch for the members 0 ... n-1, at most MaxConcurrentFetches members are fetched in parallel.
+// No new fetches are started after an error and, as for sequential processing, the error of the first failed member
+// is returned.
+func (r *Redfish) fetchMembers(ctx context.Context, n int, fetch func(context.Context, int) error) error {
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var failed bool
+	var errs = make([]error, n)
+	var jobs = make(chan int)
+
+	workers := r.MaxConcurrentFetches
+	if workers > n {
+		workers = n
+	}
+
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			err := fetch(ctx, i)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				err := fetch(ctx, i)
+				if err != nil {
+					lock.Lock()
+					errs[i] = err
+					failed = true
+					lock.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		lock.Lock()
+		stop := failed
+		lock.Unlock()
+
+		if stop || ctx.Err() != nil {
+			break
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
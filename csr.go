@@ -1,15 +1,15 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 	"strings"
 )
 
-func (r *Redfish) fetchCSRHP(mgr *ManagerData) (string, error) {
+func (r *Redfish) fetchCSRHP(ctx context.Context, mgr *ManagerData) (string, error) {
 	var csr string
 	var oemHp ManagerDataOemHp
 	var secsvc string
@@ -29,12 +29,12 @@ func (r *Redfish) fetchCSRHP(mgr *ManagerData) (string, error) {
 	}
 	secsvc = *oemHp.Hp.Links.SecurityService.ID
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return csr, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return csr, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -43,10 +43,10 @@ func (r *Redfish) fetchCSRHP(mgr *ManagerData) (string, error) {
 			"path":               secsvc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to security service")
 	}
-	response, err := r.httpRequest(secsvc, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, secsvc, "GET", nil, nil, false)
 	if err != nil {
 		return csr, err
 	}
@@ -54,7 +54,7 @@ func (r *Redfish) fetchCSRHP(mgr *ManagerData) (string, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csr, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csr, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &oemSSvc)
@@ -69,7 +69,7 @@ func (r *Redfish) fetchCSRHP(mgr *ManagerData) (string, error) {
 	httpscertloc = *oemSSvc.Links.HTTPSCert.ID
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -78,10 +78,10 @@ func (r *Redfish) fetchCSRHP(mgr *ManagerData) (string, error) {
 			"path":               httpscertloc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting certficate signing request")
 	}
-	response, err = r.httpRequest(httpscertloc, "GET", nil, nil, false)
+	response, err = r.httpRequest(ctx, httpscertloc, "GET", nil, nil, false)
 	if err != nil {
 		return csr, err
 	}
@@ -89,7 +89,7 @@ func (r *Redfish) fetchCSRHP(mgr *ManagerData) (string, error) {
 	raw = response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csr, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csr, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &httpscert)
@@ -109,7 +109,7 @@ func (r *Redfish) fetchCSRHP(mgr *ManagerData) (string, error) {
 	return csr, nil
 }
 
-func (r *Redfish) fetchCSRHPE(mgr *ManagerData) (string, error) {
+func (r *Redfish) fetchCSRHPE(ctx context.Context, mgr *ManagerData) (string, error) {
 	var csr string
 	var oemHpe ManagerDataOemHpe
 	var secsvc string
@@ -129,12 +129,12 @@ func (r *Redfish) fetchCSRHPE(mgr *ManagerData) (string, error) {
 	}
 	secsvc = *oemHpe.Hpe.Links.SecurityService.ID
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return csr, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return csr, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -143,10 +143,10 @@ func (r *Redfish) fetchCSRHPE(mgr *ManagerData) (string, error) {
 			"path":               secsvc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to security service")
 	}
-	response, err := r.httpRequest(secsvc, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, secsvc, "GET", nil, nil, false)
 	if err != nil {
 		return csr, err
 	}
@@ -154,7 +154,7 @@ func (r *Redfish) fetchCSRHPE(mgr *ManagerData) (string, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csr, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csr, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &oemSSvc)
@@ -169,7 +169,7 @@ func (r *Redfish) fetchCSRHPE(mgr *ManagerData) (string, error) {
 	httpscertloc = *oemSSvc.Links.HTTPSCert.ID
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -178,10 +178,10 @@ func (r *Redfish) fetchCSRHPE(mgr *ManagerData) (string, error) {
 			"path":               httpscertloc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting certficate signing request")
 	}
-	response, err = r.httpRequest(httpscertloc, "GET", nil, nil, false)
+	response, err = r.httpRequest(ctx, httpscertloc, "GET", nil, nil, false)
 	if err != nil {
 		return csr, err
 	}
@@ -189,7 +189,7 @@ func (r *Redfish) fetchCSRHPE(mgr *ManagerData) (string, error) {
 	raw = response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csr, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csr, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &httpscert)
@@ -209,7 +209,7 @@ func (r *Redfish) fetchCSRHPE(mgr *ManagerData) (string, error) {
 	return csr, nil
 }
 
-func (r *Redfish) fetchCSRHuawei(mgr *ManagerData) (string, error) {
+func (r *Redfish) fetchCSRHuawei(ctx context.Context, mgr *ManagerData) (string, error) {
 	var csr string
 	var oemHuawei ManagerDataOemHuawei
 	var secsvc string
@@ -229,12 +229,12 @@ func (r *Redfish) fetchCSRHuawei(mgr *ManagerData) (string, error) {
 	}
 	secsvc = *oemHuawei.Huawei.SecurityService.ID
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return csr, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return csr, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -243,10 +243,10 @@ func (r *Redfish) fetchCSRHuawei(mgr *ManagerData) (string, error) {
 			"path":               secsvc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to security service")
 	}
-	response, err := r.httpRequest(secsvc, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, secsvc, "GET", nil, nil, false)
 	if err != nil {
 		return csr, err
 	}
@@ -254,7 +254,7 @@ func (r *Redfish) fetchCSRHuawei(mgr *ManagerData) (string, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csr, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csr, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &oemSSvc)
@@ -269,7 +269,7 @@ func (r *Redfish) fetchCSRHuawei(mgr *ManagerData) (string, error) {
 	httpscertloc = *oemSSvc.Links.HTTPSCert.ID
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -278,10 +278,10 @@ func (r *Redfish) fetchCSRHuawei(mgr *ManagerData) (string, error) {
 			"path":               httpscertloc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting certficate signing request")
 	}
-	response, err = r.httpRequest(httpscertloc, "GET", nil, nil, false)
+	response, err = r.httpRequest(ctx, httpscertloc, "GET", nil, nil, false)
 	if err != nil {
 		return csr, err
 	}
@@ -289,7 +289,7 @@ func (r *Redfish) fetchCSRHuawei(mgr *ManagerData) (string, error) {
 	raw = response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csr, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csr, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &httpscert)
@@ -309,7 +309,7 @@ func (r *Redfish) fetchCSRHuawei(mgr *ManagerData) (string, error) {
 	return csr, nil
 }
 
-func (r *Redfish) getCSRTargetHP(mgr *ManagerData) (string, error) {
+func (r *Redfish) getCSRTargetHP(ctx context.Context, mgr *ManagerData) (string, error) {
 	var csrTarget string
 	var oemHp ManagerDataOemHp
 	var secsvc string
@@ -329,12 +329,12 @@ func (r *Redfish) getCSRTargetHP(mgr *ManagerData) (string, error) {
 	}
 	secsvc = *oemHp.Hp.Links.SecurityService.ID
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return csrTarget, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return csrTarget, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -343,10 +343,10 @@ func (r *Redfish) getCSRTargetHP(mgr *ManagerData) (string, error) {
 			"path":               secsvc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to security service")
 	}
-	response, err := r.httpRequest(secsvc, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, secsvc, "GET", nil, nil, false)
 	if err != nil {
 		return csrTarget, err
 	}
@@ -354,7 +354,7 @@ func (r *Redfish) getCSRTargetHP(mgr *ManagerData) (string, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csrTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csrTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &oemSSvc)
@@ -369,7 +369,7 @@ func (r *Redfish) getCSRTargetHP(mgr *ManagerData) (string, error) {
 	httpscertloc = *oemSSvc.Links.HTTPSCert.ID
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -378,10 +378,10 @@ func (r *Redfish) getCSRTargetHP(mgr *ManagerData) (string, error) {
 			"path":               httpscertloc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to certificate signing request")
 	}
-	response, err = r.httpRequest(httpscertloc, "GET", nil, nil, false)
+	response, err = r.httpRequest(ctx, httpscertloc, "GET", nil, nil, false)
 
 	if err != nil {
 		return csrTarget, err
@@ -390,7 +390,7 @@ func (r *Redfish) getCSRTargetHP(mgr *ManagerData) (string, error) {
 	raw = response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csrTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csrTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &httpscert)
@@ -406,7 +406,7 @@ func (r *Redfish) getCSRTargetHP(mgr *ManagerData) (string, error) {
 	return csrTarget, nil
 }
 
-func (r *Redfish) getCSRTargetHPE(mgr *ManagerData) (string, error) {
+func (r *Redfish) getCSRTargetHPE(ctx context.Context, mgr *ManagerData) (string, error) {
 	var csrTarget string
 	var oemHpe ManagerDataOemHpe
 	var secsvc string
@@ -426,12 +426,12 @@ func (r *Redfish) getCSRTargetHPE(mgr *ManagerData) (string, error) {
 	}
 	secsvc = *oemHpe.Hpe.Links.SecurityService.ID
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return csrTarget, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return csrTarget, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -440,10 +440,10 @@ func (r *Redfish) getCSRTargetHPE(mgr *ManagerData) (string, error) {
 			"path":               secsvc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to security service")
 	}
-	response, err := r.httpRequest(secsvc, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, secsvc, "GET", nil, nil, false)
 	if err != nil {
 		return csrTarget, err
 	}
@@ -451,7 +451,7 @@ func (r *Redfish) getCSRTargetHPE(mgr *ManagerData) (string, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csrTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csrTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &oemSSvc)
@@ -466,7 +466,7 @@ func (r *Redfish) getCSRTargetHPE(mgr *ManagerData) (string, error) {
 	httpscertloc = *oemSSvc.Links.HTTPSCert.ID
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -475,10 +475,10 @@ func (r *Redfish) getCSRTargetHPE(mgr *ManagerData) (string, error) {
 			"path":               httpscertloc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to certificate signing request")
 	}
-	response, err = r.httpRequest(httpscertloc, "GET", nil, nil, false)
+	response, err = r.httpRequest(ctx, httpscertloc, "GET", nil, nil, false)
 
 	if err != nil {
 		return csrTarget, err
@@ -487,7 +487,7 @@ func (r *Redfish) getCSRTargetHPE(mgr *ManagerData) (string, error) {
 	raw = response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csrTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csrTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &httpscert)
@@ -503,7 +503,7 @@ func (r *Redfish) getCSRTargetHPE(mgr *ManagerData) (string, error) {
 	return csrTarget, nil
 }
 
-func (r *Redfish) getCSRTargetHuawei(mgr *ManagerData) (string, error) {
+func (r *Redfish) getCSRTargetHuawei(ctx context.Context, mgr *ManagerData) (string, error) {
 	var csrTarget string
 	var oemHuawei ManagerDataOemHuawei
 	var secsvc string
@@ -523,12 +523,12 @@ func (r *Redfish) getCSRTargetHuawei(mgr *ManagerData) (string, error) {
 	}
 	secsvc = *oemHuawei.Huawei.SecurityService.ID
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return csrTarget, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return csrTarget, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -537,10 +537,10 @@ func (r *Redfish) getCSRTargetHuawei(mgr *ManagerData) (string, error) {
 			"path":               secsvc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to security service")
 	}
-	response, err := r.httpRequest(secsvc, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, secsvc, "GET", nil, nil, false)
 	if err != nil {
 		return csrTarget, err
 	}
@@ -548,7 +548,7 @@ func (r *Redfish) getCSRTargetHuawei(mgr *ManagerData) (string, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csrTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csrTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &oemSSvc)
@@ -563,7 +563,7 @@ func (r *Redfish) getCSRTargetHuawei(mgr *ManagerData) (string, error) {
 	httpscertloc = *oemSSvc.Links.HTTPSCert.ID
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -572,10 +572,10 @@ func (r *Redfish) getCSRTargetHuawei(mgr *ManagerData) (string, error) {
 			"path":               httpscertloc,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting path to certificate signing request")
 	}
-	response, err = r.httpRequest(httpscertloc, "GET", nil, nil, false)
+	response, err = r.httpRequest(ctx, httpscertloc, "GET", nil, nil, false)
 
 	if err != nil {
 		return csrTarget, err
@@ -584,7 +584,7 @@ func (r *Redfish) getCSRTargetHuawei(mgr *ManagerData) (string, error) {
 	raw = response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return csrTarget, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return csrTarget, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &httpscert)
@@ -600,10 +600,8 @@ func (r *Redfish) getCSRTargetHuawei(mgr *ManagerData) (string, error) {
 	return csrTarget, nil
 }
 
-func (r *Redfish) makeCSRPayloadHP(csr CSRData) string {
+func (r *Redfish) makeCSRPayloadHP(csr CSRData) (string, error) {
 	// Note: HPE uses the same format as HP
-	var csrstr string
-
 	if csr.C == "" {
 		csr.C = "XX"
 	}
@@ -620,67 +618,44 @@ func (r *Redfish) makeCSRPayloadHP(csr CSRData) string {
 		csr.OU = "-"
 	}
 
-	csrstr += fmt.Sprintf("\"Country\": \"%s\", ", csr.C)
-	csrstr += fmt.Sprintf("\"State\": \"%s\", ", csr.S)
-	csrstr += fmt.Sprintf("\"City\": \"%s\", ", csr.L)
-	csrstr += fmt.Sprintf("\"OrgName\": \"%s\", ", csr.O)
-	csrstr += fmt.Sprintf("\"OrgUnit\": \"%s\", ", csr.OU)
-
-	if csr.CN != "" {
-		csrstr += fmt.Sprintf("\"CommonName\": \"%s\" ", csr.CN)
-	} else {
-		csrstr += fmt.Sprintf("\"CommonName\": \"%s\" ", r.Hostname)
-	}
-
-	csrstr = "{ " + csrstr + " } "
-	return csrstr
+	return r.marshalCSRPayload(csr)
 }
 
-func (r *Redfish) makeCSRPayloadVanilla(csr CSRData) string {
-	var csrstr string
-
-	if csr.C != "" {
-		csrstr += fmt.Sprintf("\"Country\": \"%s\", ", csr.C)
-	} else {
-		csrstr += "XX"
-	}
-
-	if csr.S != "" {
-		csrstr += fmt.Sprintf("\"State\": \"%s\", ", csr.S)
+func (r *Redfish) makeCSRPayloadVanilla(csr CSRData) (string, error) {
+	if csr.C == "" {
+		csr.C = "XX"
 	}
 
-	if csr.L != "" {
-		csrstr += fmt.Sprintf("\"City\": \"%s\", ", csr.L)
-	}
+	return r.marshalCSRPayload(csr)
+}
 
-	if csr.O != "" {
-		csrstr += fmt.Sprintf("\"OrgName\": \"%s\", ", csr.O)
+func (r *Redfish) marshalCSRPayload(csr CSRData) (string, error) {
+	var payload = csrPayload{
+		Country:    csr.C,
+		State:      csr.S,
+		City:       csr.L,
+		OrgName:    csr.O,
+		OrgUnit:    csr.OU,
+		CommonName: csr.CN,
 	}
 
-	if csr.OU != "" {
-		csrstr += fmt.Sprintf("\"OrgUnit\": \"%s\", ", csr.OU)
+	if payload.CommonName == "" {
+		payload.CommonName = r.Hostname
 	}
 
-	if csr.CN != "" {
-		csrstr += fmt.Sprintf("\"CommonName\": \"%s\" ", csr.CN)
-	} else {
-		csrstr += fmt.Sprintf("\"CommonName\": \"%s\" ", r.Hostname)
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
 	}
-
-	csrstr = "{ " + csrstr + " } "
-	return csrstr
+	return string(raw), nil
 }
 
-func (r *Redfish) makeCSRPayload(csr CSRData) string {
-	var csrstr string
-
+func (r *Redfish) makeCSRPayload(csr CSRData) (string, error) {
 	if r.Flavor == RedfishHP || r.Flavor == RedfishHPE {
-		csrstr = r.makeCSRPayloadHP(csr)
-	} else {
-		csrstr = r.makeCSRPayloadVanilla(csr)
+		return r.makeCSRPayloadHP(csr)
 	}
 
-	return csrstr
+	return r.makeCSRPayloadVanilla(csr)
 }
 
 func (r *Redfish) validateCSRData(csr CSRData) error {
@@ -728,68 +703,96 @@ func (r *Redfish) validateCSRData(csr CSRData) error {
 
 // GenCSR - generate CSR
 func (r *Redfish) GenCSR(csr CSRData) error {
+	return r.GenCSRContext(context.Background(), csr)
+}
+
+// GenCSRContext - same as GenCSR but uses the supplied context for all HTTP requests
+func (r *Redfish) GenCSRContext(ctx context.Context, csr CSRData) error {
+	_, err := r.genCSR(ctx, csr)
+	return err
+}
+
+// GenCSRTask - generate CSR and return the task if the service processor processes the request asynchronously,
+// the task is nil if the request has been completed
+func (r *Redfish) GenCSRTask(csr CSRData) (*Task, error) {
+	return r.GenCSRTaskContext(context.Background(), csr)
+}
+
+// GenCSRTaskContext - same as GenCSRTask but uses the supplied context for all HTTP requests
+func (r *Redfish) GenCSRTaskContext(ctx context.Context, csr CSRData) (*Task, error) {
+	response, err := r.genCSR(ctx, csr)
+	if err != nil {
+		return nil, err
+	}
+	return TaskFromResult(response), nil
+}
+
+func (r *Redfish) genCSR(ctx context.Context, csr CSRData) (HTTPResult, error) {
 	var csrstr string
 	var gencsrtarget string
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return HTTPResult{}, ErrNotAuthenticated
 	}
 
 	// set vendor flavor
-	err := r.GetVendorFlavor()
+	err := r.GetVendorFlavorContext(ctx)
 	if err != nil {
-		return err
+		return HTTPResult{}, err
 	}
 
 	err = r.validateCSRData(csr)
 	if err != nil {
-		return err
+		return HTTPResult{}, err
 	}
 
-	csrstr = r.makeCSRPayload(csr)
+	csrstr, err = r.makeCSRPayload(csr)
+	if err != nil {
+		return HTTPResult{}, err
+	}
 
 	// get list of Manager endpoint
-	mgrList, err := r.GetManagers()
+	mgrList, err := r.GetManagersContext(ctx)
 	if err != nil {
-		return err
+		return HTTPResult{}, err
 	}
 
 	// pick the first entry
-	mgr0, err := r.GetManagerData(mgrList[0])
+	mgr0, err := r.GetManagerDataContext(ctx, mgrList[0])
 	if err != nil {
-		return err
+		return HTTPResult{}, err
 	}
 
 	// get endpoint SecurityService from Managers
 	if r.Flavor == RedfishHP {
-		gencsrtarget, err = r.getCSRTargetHP(mgr0)
+		gencsrtarget, err = r.getCSRTargetHP(ctx, mgr0)
 		if err != nil {
-			return err
+			return HTTPResult{}, err
 		}
 	} else if r.Flavor == RedfishHPE {
-		gencsrtarget, err = r.getCSRTargetHPE(mgr0)
+		gencsrtarget, err = r.getCSRTargetHPE(ctx, mgr0)
 		if err != nil {
-			return err
+			return HTTPResult{}, err
 		}
 	} else if r.Flavor == RedfishHuawei {
-		gencsrtarget, err = r.getCSRTargetHuawei(mgr0)
+		gencsrtarget, err = r.getCSRTargetHuawei(ctx, mgr0)
 		if err != nil {
-			return err
+			return HTTPResult{}, err
 		}
 	} else if r.Flavor == RedfishInspur {
-		return errors.New("Inspur management boards do not support CSR generation")
+		return HTTPResult{}, r.newNotSupportedError("Inspur management boards do not support CSR generation")
 	} else if r.Flavor == RedfishSuperMicro {
-		return errors.New("SuperMicro management boards do not support CSR generation")
+		return HTTPResult{}, r.newNotSupportedError("SuperMicro management boards do not support CSR generation")
 	} else {
-		return errors.New("Unable to get vendor for management board. If this vendor supports CSR generation please file a feature request")
+		return HTTPResult{}, r.newNotSupportedError("Unable to get vendor for management board. If this vendor supports CSR generation please file a feature request")
 	}
 
 	if gencsrtarget == "" {
-		return errors.New("BUG: CSR generation target is not known")
+		return HTTPResult{}, errors.New("BUG: CSR generation target is not known")
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -798,11 +801,11 @@ func (r *Redfish) GenCSR(csr CSRData) error {
 			"path":               gencsrtarget,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting CSR generation")
 	}
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -811,13 +814,13 @@ func (r *Redfish) GenCSR(csr CSRData) error {
 			"path":               gencsrtarget,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
-			"payload":            csrstr,
+			"use_basic_auth":     r.UseBasicAuth,
+			"payload":            redactPayload(csrstr),
 		}).Debug("Requesting CSR generation")
 	}
-	response, err := r.httpRequest(gencsrtarget, "POST", nil, strings.NewReader(csrstr), false)
+	response, err := r.httpRequest(ctx, gencsrtarget, "POST", nil, strings.NewReader(csrstr), false)
 	if err != nil {
-		return err
+		return response, err
 	}
 	// XXX: do we need to look at the content returned by HTTP POST ?
 
@@ -828,56 +831,61 @@ func (r *Redfish) GenCSR(csr CSRData) error {
 		fallthrough
 	case http.StatusAccepted:
 	default:
-		return fmt.Errorf("HTTP POST to %s returned \"%s\" instead of \"200 OK\", \"201 Created\" or \"202 Accepted\"", response.URL, response.Status)
+		return response, r.newHTTPError("POST", response, http.StatusOK, http.StatusCreated, http.StatusAccepted)
 	}
 
-	return nil
+	return response, nil
 }
 
 // FetchCSR - fetch CSR
 func (r *Redfish) FetchCSR() (string, error) {
+	return r.FetchCSRContext(context.Background())
+}
+
+// FetchCSRContext - same as FetchCSR but uses the supplied context for all HTTP requests
+func (r *Redfish) FetchCSRContext(ctx context.Context) (string, error) {
 	var csrstr string
 
 	// set vendor flavor
-	err := r.GetVendorFlavor()
+	err := r.GetVendorFlavorContext(ctx)
 	if err != nil {
 		return csrstr, err
 	}
 
 	// get list of Manager endpoint
-	mgrList, err := r.GetManagers()
+	mgrList, err := r.GetManagersContext(ctx)
 	if err != nil {
 		return csrstr, err
 	}
 
 	// pick the first entry
-	mgr0, err := r.GetManagerData(mgrList[0])
+	mgr0, err := r.GetManagerDataContext(ctx, mgrList[0])
 	if err != nil {
 		return csrstr, err
 	}
 
 	// get endpoint SecurityService from Managers
 	if r.Flavor == RedfishHP {
-		csrstr, err = r.fetchCSRHP(mgr0)
+		csrstr, err = r.fetchCSRHP(ctx, mgr0)
 		if err != nil {
 			return csrstr, err
 		}
 	} else if r.Flavor == RedfishHPE {
-		csrstr, err = r.fetchCSRHPE(mgr0)
+		csrstr, err = r.fetchCSRHPE(ctx, mgr0)
 		if err != nil {
 			return csrstr, err
 		}
 	} else if r.Flavor == RedfishHuawei {
-		csrstr, err = r.fetchCSRHuawei(mgr0)
+		csrstr, err = r.fetchCSRHuawei(ctx, mgr0)
 		if err != nil {
 			return csrstr, err
 		}
 	} else if r.Flavor == RedfishInspur {
-		return csrstr, errors.New("Inspur management boards do not support CSR generation")
+		return csrstr, r.newNotSupportedError("Inspur management boards do not support CSR generation")
 	} else if r.Flavor == RedfishSuperMicro {
-		return csrstr, errors.New("SuperMicro management boards do not support CSR generation")
+		return csrstr, r.newNotSupportedError("SuperMicro management boards do not support CSR generation")
 	} else {
-		return csrstr, errors.New("Unable to get vendor for management board. If this vendor supports CSR generation please file a feature request")
+		return csrstr, r.newNotSupportedError("Unable to get vendor for management board. If this vendor supports CSR generation please file a feature request")
 	}
 
 	// convert "raw" string (new lines escaped as \n) to real string (new lines are new lines)
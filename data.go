@@ -1,9 +1,14 @@
 package redfish
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	log "github.com/sirupsen/logrus"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 )
 
@@ -43,6 +48,7 @@ type OData struct {
 	Context      *string `json:"@odata.context"`
 	Members      []OData `json:"Members"`
 	MembersCount int     `json:"Members@odata.count"`
+	NextLink     *string `json:"Members@odata.nextLink"`
 }
 
 type baseEndpoint struct {
@@ -51,7 +57,28 @@ type baseEndpoint struct {
 	Managers       OData             `json:"Managers"`
 	SessionService OData             `json:"SessionService"`
 	Systems        OData             `json:"Systems"`
+	Registries     OData             `json:"Registries"`
 	Links          baseEndpointLinks `json:"Links"`
+
+	ProtocolFeaturesSupported *ProtocolFeaturesSupported `json:"ProtocolFeaturesSupported"`
+	Oem                       map[string]json.RawMessage `json:"Oem"`
+}
+
+// ProtocolFeaturesSupported - optional protocol features as reported by the service root
+type ProtocolFeaturesSupported struct {
+	ExpandQuery  *ProtocolFeaturesExpandQuery `json:"ExpandQuery"`
+	FilterQuery  bool                         `json:"FilterQuery"`
+	SelectQuery  bool                         `json:"SelectQuery"`
+	TopSkipQuery bool                         `json:"TopSkipQuery"`
+}
+
+// ProtocolFeaturesExpandQuery - supported options of the $expand query parameter
+type ProtocolFeaturesExpandQuery struct {
+	ExpandAll bool `json:"ExpandAll"`
+	Levels    bool `json:"Levels"`
+	Links     bool `json:"Links"`
+	NoLinks   bool `json:"NoLinks"`
+	MaxLevels int  `json:"MaxLevels"`
 }
 
 type baseEndpointLinks struct {
@@ -84,6 +111,67 @@ type SystemMemorySummary struct {
 	Status               Status  `json:"Status"`
 }
 
+// SystemBoot - boot settings of a system
+type SystemBoot struct {
+	BootSourceOverrideTarget        *string  `json:"BootSourceOverrideTarget"`
+	BootSourceOverrideTargetValues  []string `json:"BootSourceOverrideTarget@Redfish.AllowableValues"`
+	BootSourceOverrideEnabled       *string  `json:"BootSourceOverrideEnabled"`
+	BootSourceOverrideEnabledValues []string `json:"BootSourceOverrideEnabled@Redfish.AllowableValues"`
+	BootSourceOverrideMode          *string  `json:"BootSourceOverrideMode"`
+	BootSourceOverrideModeValues    []string `json:"BootSourceOverrideMode@Redfish.AllowableValues"`
+	UefiTargetBootSourceOverride    *string  `json:"UefiTargetBootSourceOverride"`
+	BootNext                        *string  `json:"BootNext"`
+	BootOrder                       []string `json:"BootOrder"`
+	BootOptions                     *OData   `json:"BootOptions"`
+}
+
+// BootOverride - boot source override for the next boot or all following boots
+type BootOverride struct {
+	// Target - boot source, e.g. Pxe, Cd, Hdd, BiosSetup or UefiTarget
+	Target string
+	// Persistent - override all following boots instead of the next boot only
+	Persistent bool
+	// Mode - optional boot mode, e.g. UEFI or Legacy
+	Mode string
+	// UefiTarget - UEFI device path, required for Target UefiTarget
+	UefiTarget string
+}
+
+// BootOptionData - boot option of a system
+type BootOptionData struct {
+	ID                  *string `json:"Id"`
+	Name                *string `json:"Name"`
+	DisplayName         *string `json:"DisplayName"`
+	BootOptionReference *string `json:"BootOptionReference"`
+	BootOptionEnabled   *bool   `json:"BootOptionEnabled"`
+	Alias               *string `json:"Alias"`
+	UefiDevicePath      *string `json:"UefiDevicePath"`
+	SelfEndpoint        *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// payload to set the boot source override
+type bootOverridePayload struct {
+	Boot bootOverridePayloadBoot `json:"Boot"`
+}
+
+type bootOverridePayloadBoot struct {
+	BootSourceOverrideTarget     string `json:"BootSourceOverrideTarget,omitempty"`
+	BootSourceOverrideEnabled    string `json:"BootSourceOverrideEnabled"`
+	BootSourceOverrideMode       string `json:"BootSourceOverrideMode,omitempty"`
+	UefiTargetBootSourceOverride string `json:"UefiTargetBootSourceOverride,omitempty"`
+}
+
+// payload to set the boot order
+type bootOrderPayload struct {
+	Boot bootOrderPayloadBoot `json:"Boot"`
+}
+
+type bootOrderPayloadBoot struct {
+	BootOrder []string `json:"BootOrder"`
+}
+
 // SystemActionsComputerReset - allowed action for computer reset
 type SystemActionsComputerReset struct {
 	Target          string   `json:"target"`
@@ -119,7 +207,7 @@ type SystemData struct {
 	SerialNumber       *string                 `json:"SerialNumber"`
 	ProcessorSummary   *SystemProcessorSummary `json:"ProcessorSummary"`
 	Processors         *OData                  `json:"Processors"`
-	PowerState         *string                 `json:"Powerstate"`
+	PowerState         *string                 `json:"PowerState"`
 	Name               *string                 `json:"Name"`
 	Model              *string                 `json:"Model"`
 	MemorySummary      *SystemMemorySummary    `json:"MemorySummary"`
@@ -131,8 +219,18 @@ type SystemData struct {
 	BIOSVersion        *string                 `json:"BiosVersion"`
 	BIOS               *OData                  `json:"Bios"`
 	Actions            *SystemActions          `json:"Actions"`
+	Boot               *SystemBoot             `json:"Boot"`
+	Storage            *OData                  `json:"Storage"`
+	SimpleStorage      *OData                  `json:"SimpleStorage"`
+	PCIeDevices        []OData                 `json:"PCIeDevices"`
+	PCIeFunctions      []OData                 `json:"PCIeFunctions"`
+	SecureBoot         *OData                  `json:"SecureBoot"`
+	TrustedModules     []TrustedModuleData     `json:"TrustedModules"`
+	Settings           *SettingsObject         `json:"@Redfish.Settings"`
 	Oem                json.RawMessage         `json:"Oem"`
 	SelfEndpoint       *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
 	// map normalized (converted to lowercase) to supported reset types
 	allowedResetTypes map[string]string
 	// name of the reset type property, usually "ResetType", but may vary (e.g. when specified otherwise in @Redfish.ActionInfo)
@@ -156,6 +254,8 @@ type AccountData struct {
 	Locked   *bool   `json:"Locked"`
 
 	SelfEndpoint *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
 }
 
 // RoleData - individual roles
@@ -167,25 +267,31 @@ type RoleData struct {
 	AssignedPrivileges []string `json:"AssignedPrivileges"`
 	//    OemPrivileges   []string    `json:"OemPrivileges"`
 	SelfEndpoint *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
 }
 
 // ChassisData - Chassis information
 type ChassisData struct {
-	ID           *string         `json:"Id"`
-	Name         *string         `json:"Name"`
-	ChassisType  *string         `json:"ChassisType"`
-	Manufacturer *string         `json:"Manufacturer"`
-	Model        *string         `json:"Model"`
-	SerialNumber *string         `json:"SerialNumber"`
-	PartNumber   *string         `json:"PartNumber"`
-	AssetTag     *string         `json:"AssetTag"`
-	IndicatorLED *string         `json:"IndicatorLED"`
-	Status       Status          `json:"Status"`
-	Oem          json.RawMessage `json:"Oem"`
-	Thermal      *OData          `json:"Thermal"`
-	Power        *OData          `json:"Power"`
+	ID              *string         `json:"Id"`
+	Name            *string         `json:"Name"`
+	ChassisType     *string         `json:"ChassisType"`
+	Manufacturer    *string         `json:"Manufacturer"`
+	Model           *string         `json:"Model"`
+	SerialNumber    *string         `json:"SerialNumber"`
+	PartNumber      *string         `json:"PartNumber"`
+	AssetTag        *string         `json:"AssetTag"`
+	IndicatorLED    *string         `json:"IndicatorLED"`
+	Status          Status          `json:"Status"`
+	Oem             json.RawMessage `json:"Oem"`
+	Thermal         *OData          `json:"Thermal"`
+	Power           *OData          `json:"Power"`
+	PCIeDevices     *OData          `json:"PCIeDevices"`
+	NetworkAdapters *OData          `json:"NetworkAdapters"`
 
 	SelfEndpoint *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
 }
 
 // TemperatureData - temperature readings
@@ -315,6 +421,637 @@ type PowerData struct {
 	SelfEndpoint  *string
 }
 
+// TaskData - task resource of an asynchronous operation
+type TaskData struct {
+	ID              *string                    `json:"Id"`
+	Name            *string                    `json:"Name"`
+	TaskState       *string                    `json:"TaskState"`
+	TaskStatus      *string                    `json:"TaskStatus"`
+	PercentComplete *int                       `json:"PercentComplete"`
+	StartTime       *string                    `json:"StartTime"`
+	EndTime         *string                    `json:"EndTime"`
+	TaskMonitor     *string                    `json:"TaskMonitor"`
+	Messages        []ErrorMessageExtendedInfo `json:"Messages"`
+	SelfEndpoint    *string
+}
+
+// SettingsObject - @Redfish.Settings annotation, references the resource for pending settings
+type SettingsObject struct {
+	SettingsObject      *OData                     `json:"SettingsObject"`
+	Time                *string                    `json:"Time"`
+	ETag                *string                    `json:"ETag"`
+	Messages            []ErrorMessageExtendedInfo `json:"Messages"`
+	SupportedApplyTimes []string                   `json:"SupportedApplyTimes"`
+}
+
+// BIOSData - BIOS attributes of a system
+type BIOSData struct {
+	ID                *string                `json:"Id"`
+	Name              *string                `json:"Name"`
+	AttributeRegistry *string                `json:"AttributeRegistry"`
+	Attributes        map[string]interface{} `json:"Attributes"`
+	Settings          *SettingsObject        `json:"@Redfish.Settings"`
+	SelfEndpoint      *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// BIOSAttributeRegistry - attribute registry describing the BIOS attributes
+type BIOSAttributeRegistry struct {
+	ID               *string                      `json:"Id"`
+	RegistryVersion  *string                      `json:"RegistryVersion"`
+	Language         *string                      `json:"Language"`
+	SupportedSystems []BIOSSupportedSystem        `json:"SupportedSystems"`
+	RegistryEntries  BIOSAttributeRegistryEntries `json:"RegistryEntries"`
+}
+
+// BIOSSupportedSystem - systems supported by the attribute registry
+type BIOSSupportedSystem struct {
+	ProductName     *string `json:"ProductName"`
+	SystemID        *string `json:"SystemId"`
+	FirmwareVersion *string `json:"FirmwareVersion"`
+}
+
+// BIOSAttributeRegistryEntries - entries of the attribute registry
+type BIOSAttributeRegistryEntries struct {
+	Attributes []BIOSAttributeDefinition `json:"Attributes"`
+}
+
+// BIOSAttributeDefinition - definition of a BIOS attribute
+type BIOSAttributeDefinition struct {
+	AttributeName   *string              `json:"AttributeName"`
+	DisplayName     *string              `json:"DisplayName"`
+	HelpText        *string              `json:"HelpText"`
+	Type            *string              `json:"Type"`
+	ReadOnly        bool                 `json:"ReadOnly"`
+	Immutable       bool                 `json:"Immutable"`
+	Hidden          bool                 `json:"Hidden"`
+	ResetRequired   bool                 `json:"ResetRequired"`
+	DefaultValue    interface{}          `json:"DefaultValue"`
+	Value           []BIOSAttributeValue `json:"Value"`
+	LowerBound      *int64               `json:"LowerBound"`
+	UpperBound      *int64               `json:"UpperBound"`
+	ScalarIncrement *int64               `json:"ScalarIncrement"`
+	MinLength       *int                 `json:"MinLength"`
+	MaxLength       *int                 `json:"MaxLength"`
+	ValueExpression *string              `json:"ValueExpression"`
+}
+
+// BIOSAttributeValue - allowed value of an enumeration attribute
+type BIOSAttributeValue struct {
+	ValueName        *string `json:"ValueName"`
+	ValueDisplayName *string `json:"ValueDisplayName"`
+}
+
+// BIOSPendingValue - current and pending value of a BIOS attribute
+type BIOSPendingValue struct {
+	Current interface{}
+	Pending interface{}
+}
+
+// payload to change BIOS attributes
+type biosAttributesPayload struct {
+	Attributes map[string]interface{} `json:"Attributes"`
+}
+
+// registry file as listed in the Registries collection
+type registryFileData struct {
+	ID       *string                `json:"Id"`
+	Registry *string                `json:"Registry"`
+	Location []registryFileLocation `json:"Location"`
+}
+
+type registryFileLocation struct {
+	Language       *string `json:"Language"`
+	URI            *string `json:"Uri"`
+	PublicationURI *string `json:"PublicationUri"`
+}
+
+// ProcessorData - processor of a system
+type ProcessorData struct {
+	ID                    *string          `json:"Id"`
+	Name                  *string          `json:"Name"`
+	Socket                *string          `json:"Socket"`
+	ProcessorType         *string          `json:"ProcessorType"`
+	ProcessorArchitecture *string          `json:"ProcessorArchitecture"`
+	InstructionSet        *string          `json:"InstructionSet"`
+	Manufacturer          *string          `json:"Manufacturer"`
+	Model                 *string          `json:"Model"`
+	MaxSpeedMHz           *int             `json:"MaxSpeedMHz"`
+	OperatingSpeedMHz     *int             `json:"OperatingSpeedMHz"`
+	TotalCores            *int             `json:"TotalCores"`
+	TotalEnabledCores     *int             `json:"TotalEnabledCores"`
+	TotalThreads          *int             `json:"TotalThreads"`
+	ProcessorID           *ProcessorIDData `json:"ProcessorId"`
+	SerialNumber          *string          `json:"SerialNumber"`
+	PartNumber            *string          `json:"PartNumber"`
+	Status                Status           `json:"Status"`
+	Oem                   json.RawMessage  `json:"Oem"`
+	SelfEndpoint          *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// ProcessorIDData - identification of a processor
+type ProcessorIDData struct {
+	VendorID                *string `json:"VendorId"`
+	IdentificationRegisters *string `json:"IdentificationRegisters"`
+	EffectiveFamily         *string `json:"EffectiveFamily"`
+	EffectiveModel          *string `json:"EffectiveModel"`
+	Step                    *string `json:"Step"`
+	MicrocodeInfo           *string `json:"MicrocodeInfo"`
+}
+
+// MemoryData - memory module (DIMM) of a system
+type MemoryData struct {
+	ID                *string             `json:"Id"`
+	Name              *string             `json:"Name"`
+	MemoryDeviceType  *string             `json:"MemoryDeviceType"`
+	MemoryType        *string             `json:"MemoryType"`
+	BaseModuleType    *string             `json:"BaseModuleType"`
+	CapacityMiB       *int                `json:"CapacityMiB"`
+	OperatingSpeedMhz *int                `json:"OperatingSpeedMhz"`
+	AllowedSpeedsMHz  []int               `json:"AllowedSpeedsMHz"`
+	DataWidthBits     *int                `json:"DataWidthBits"`
+	BusWidthBits      *int                `json:"BusWidthBits"`
+	RankCount         *int                `json:"RankCount"`
+	ErrorCorrection   *string             `json:"ErrorCorrection"`
+	Manufacturer      *string             `json:"Manufacturer"`
+	PartNumber        *string             `json:"PartNumber"`
+	SerialNumber      *string             `json:"SerialNumber"`
+	DeviceLocator     *string             `json:"DeviceLocator"`
+	MemoryLocation    *MemoryLocationData `json:"MemoryLocation"`
+	Enabled           *bool               `json:"Enabled"`
+	Status            Status              `json:"Status"`
+	Oem               json.RawMessage     `json:"Oem"`
+	SelfEndpoint      *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// MemoryLocationData - location of a memory module
+type MemoryLocationData struct {
+	Socket           *int `json:"Socket"`
+	MemoryController *int `json:"MemoryController"`
+	Channel          *int `json:"Channel"`
+	Slot             *int `json:"Slot"`
+}
+
+// StorageData - storage subsystem of a system
+type StorageData struct {
+	ID                 *string                 `json:"Id"`
+	Name               *string                 `json:"Name"`
+	Description        *string                 `json:"Description"`
+	StorageControllers []StorageControllerData `json:"StorageControllers"`
+	Drives             []OData                 `json:"Drives"`
+	Volumes            *OData                  `json:"Volumes"`
+	Status             Status                  `json:"Status"`
+	Oem                json.RawMessage         `json:"Oem"`
+	SelfEndpoint       *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// StorageControllerData - storage controller (e.g. RAID controller) of a storage subsystem
+type StorageControllerData struct {
+	MemberID                     *string               `json:"MemberId"`
+	Name                         *string               `json:"Name"`
+	Manufacturer                 *string               `json:"Manufacturer"`
+	Model                        *string               `json:"Model"`
+	SerialNumber                 *string               `json:"SerialNumber"`
+	PartNumber                   *string               `json:"PartNumber"`
+	FirmwareVersion              *string               `json:"FirmwareVersion"`
+	SpeedGbps                    *float64              `json:"SpeedGbps"`
+	SupportedControllerProtocols []string              `json:"SupportedControllerProtocols"`
+	SupportedDeviceProtocols     []string              `json:"SupportedDeviceProtocols"`
+	SupportedRAIDTypes           []string              `json:"SupportedRAIDTypes"`
+	Location                     *PhysicalLocationData `json:"Location"`
+	Status                       Status                `json:"Status"`
+}
+
+// PhysicalLocationData - physical location of a component
+type PhysicalLocationData struct {
+	PartLocation *PartLocationData `json:"PartLocation"`
+}
+
+// PartLocationData - location of a part within its enclosure
+type PartLocationData struct {
+	ServiceLabel         *string `json:"ServiceLabel"`
+	LocationType         *string `json:"LocationType"`
+	LocationOrdinalValue *int    `json:"LocationOrdinalValue"`
+}
+
+// DriveData - physical drive of a storage subsystem
+type DriveData struct {
+	ID                            *string               `json:"Id"`
+	Name                          *string               `json:"Name"`
+	Manufacturer                  *string               `json:"Manufacturer"`
+	Model                         *string               `json:"Model"`
+	SerialNumber                  *string               `json:"SerialNumber"`
+	PartNumber                    *string               `json:"PartNumber"`
+	Revision                      *string               `json:"Revision"`
+	CapacityBytes                 *int64                `json:"CapacityBytes"`
+	BlockSizeBytes                *int                  `json:"BlockSizeBytes"`
+	MediaType                     *string               `json:"MediaType"`
+	Protocol                      *string               `json:"Protocol"`
+	RotationSpeedRPM              *float64              `json:"RotationSpeedRPM"`
+	PredictedMediaLifeLeftPercent *float64              `json:"PredictedMediaLifeLeftPercent"`
+	FailurePredicted              *bool                 `json:"FailurePredicted"`
+	HotspareType                  *string               `json:"HotspareType"`
+	PhysicalLocation              *PhysicalLocationData `json:"PhysicalLocation"`
+	Actions                       *DriveActions         `json:"Actions"`
+	Status                        Status                `json:"Status"`
+	Oem                           json.RawMessage       `json:"Oem"`
+	SelfEndpoint                  *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// DriveActions - supported actions of a drive
+type DriveActions struct {
+	SecureErase *ActionTarget `json:"#Drive.SecureErase"`
+}
+
+// ActionTarget - target of an action
+type ActionTarget struct {
+	Target string `json:"target"`
+}
+
+// VolumeData - logical volume of a storage subsystem
+type VolumeData struct {
+	ID             *string         `json:"Id"`
+	Name           *string         `json:"Name"`
+	CapacityBytes  *int64          `json:"CapacityBytes"`
+	BlockSizeBytes *int            `json:"BlockSizeBytes"`
+	VolumeType     *string         `json:"VolumeType"`
+	RAIDType       *string         `json:"RAIDType"`
+	Encrypted      *bool           `json:"Encrypted"`
+	Links          *VolumeLinks    `json:"Links"`
+	Status         Status          `json:"Status"`
+	Oem            json.RawMessage `json:"Oem"`
+	SelfEndpoint   *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// VolumeLinks - resources related to a volume
+type VolumeLinks struct {
+	Drives []OData `json:"Drives"`
+}
+
+// VolumeCreateData - data for volume creation
+type VolumeCreateData struct {
+	// Name - name of the new volume, optional
+	Name string
+	// RAIDType - RAID type, e.g. RAID1, checked against SupportedRAIDTypes of the storage controllers if reported
+	RAIDType string
+	// CapacityBytes - capacity of the new volume, 0 to use the maximal capacity of the drives
+	CapacityBytes int64
+	// Drives - endpoints of the drives for the new volume
+	Drives []string
+}
+
+// payload for volume creation, empty optional fields are omitted
+type volumeCreatePayload struct {
+	Name          string                   `json:"Name,omitempty"`
+	RAIDType      string                   `json:"RAIDType,omitempty"`
+	CapacityBytes int64                    `json:"CapacityBytes,omitempty"`
+	Links         volumeCreatePayloadLinks `json:"Links"`
+}
+
+type volumeCreatePayloadLinks struct {
+	Drives []odataLink `json:"Drives"`
+}
+
+// reference to a resource in a payload
+type odataLink struct {
+	ID string `json:"@odata.id"`
+}
+
+// SimpleStorageData - simple storage controller of a system, used by service processors without Storage resources
+type SimpleStorageData struct {
+	ID             *string               `json:"Id"`
+	Name           *string               `json:"Name"`
+	UefiDevicePath *string               `json:"UefiDevicePath"`
+	Devices        []SimpleStorageDevice `json:"Devices"`
+	Status         Status                `json:"Status"`
+	Oem            json.RawMessage       `json:"Oem"`
+	SelfEndpoint   *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// SimpleStorageDevice - device attached to a simple storage controller
+type SimpleStorageDevice struct {
+	Name          *string `json:"Name"`
+	Manufacturer  *string `json:"Manufacturer"`
+	Model         *string `json:"Model"`
+	CapacityBytes *int64  `json:"CapacityBytes"`
+	Status        Status  `json:"Status"`
+}
+
+// LogServiceData - log service of a system or a manager
+type LogServiceData struct {
+	ID                 *string            `json:"Id"`
+	Name               *string            `json:"Name"`
+	Description        *string            `json:"Description"`
+	LogEntryType       *string            `json:"LogEntryType"`
+	MaxNumberOfRecords *int               `json:"MaxNumberOfRecords"`
+	OverWritePolicy    *string            `json:"OverWritePolicy"`
+	DateTime           *string            `json:"DateTime"`
+	ServiceEnabled     *bool              `json:"ServiceEnabled"`
+	Entries            *OData             `json:"Entries"`
+	Actions            *LogServiceActions `json:"Actions"`
+	Status             Status             `json:"Status"`
+	Oem                json.RawMessage    `json:"Oem"`
+	SelfEndpoint       *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// LogServiceActions - supported actions of a log service
+type LogServiceActions struct {
+	ClearLog *ActionTarget `json:"#LogService.ClearLog"`
+}
+
+// LogEntryData - entry of a log service
+type LogEntryData struct {
+	ID              *string         `json:"Id"`
+	Name            *string         `json:"Name"`
+	EntryType       *string         `json:"EntryType"`
+	Severity        *string         `json:"Severity"`
+	Created         *string         `json:"Created"`
+	Message         *string         `json:"Message"`
+	MessageID       *string         `json:"MessageId"`
+	MessageArgs     []string        `json:"MessageArgs"`
+	SensorType      *string         `json:"SensorType"`
+	SensorNumber    *int            `json:"SensorNumber"`
+	EntryCode       *string         `json:"EntryCode"`
+	EventType       *string         `json:"EventType"`
+	OemRecordFormat *string         `json:"OemRecordFormat"`
+	Oem             json.RawMessage `json:"Oem"`
+	SelfEndpoint    *string
+}
+
+// EthernetInterfaceData - network interface of a system
+type EthernetInterfaceData struct {
+	ID                  *string           `json:"Id"`
+	Name                *string           `json:"Name"`
+	Description         *string           `json:"Description"`
+	MACAddress          *string           `json:"MACAddress"`
+	PermanentMACAddress *string           `json:"PermanentMACAddress"`
+	LinkStatus          *string           `json:"LinkStatus"`
+	InterfaceEnabled    *bool             `json:"InterfaceEnabled"`
+	SpeedMbps           *int              `json:"SpeedMbps"`
+	FullDuplex          *bool             `json:"FullDuplex"`
+	AutoNeg             *bool             `json:"AutoNeg"`
+	MTUSize             *int              `json:"MTUSize"`
+	HostName            *string           `json:"HostName"`
+	FQDN                *string           `json:"FQDN"`
+	IPv4Addresses       []IPv4AddressData `json:"IPv4Addresses"`
+	IPv6Addresses       []IPv6AddressData `json:"IPv6Addresses"`
+	IPv6DefaultGateway  *string           `json:"IPv6DefaultGateway"`
+	NameServers         []string          `json:"NameServers"`
+	VLAN                *VLANData         `json:"VLAN"`
+	VLANs               *OData            `json:"VLANs"`
+	Status              Status            `json:"Status"`
+	Oem                 json.RawMessage   `json:"Oem"`
+	SelfEndpoint        *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// IPv4AddressData - IPv4 address of a network interface
+type IPv4AddressData struct {
+	Address       *string `json:"Address"`
+	SubnetMask    *string `json:"SubnetMask"`
+	AddressOrigin *string `json:"AddressOrigin"`
+	Gateway       *string `json:"Gateway"`
+}
+
+// IPv6AddressData - IPv6 address of a network interface
+type IPv6AddressData struct {
+	Address       *string `json:"Address"`
+	PrefixLength  *int    `json:"PrefixLength"`
+	AddressOrigin *string `json:"AddressOrigin"`
+	AddressState  *string `json:"AddressState"`
+}
+
+// VLANData - VLAN settings of a network interface
+type VLANData struct {
+	VLANEnable *bool `json:"VLANEnable"`
+	VLANID     *int  `json:"VLANId"`
+}
+
+// VLANNetworkInterfaceData - VLAN of a network interface with multiple VLANs
+type VLANNetworkInterfaceData struct {
+	ID           *string `json:"Id"`
+	Name         *string `json:"Name"`
+	VLANEnable   *bool   `json:"VLANEnable"`
+	VLANID       *int    `json:"VLANId"`
+	SelfEndpoint *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// PCIeDeviceData - PCIe device (e.g. expansion card)
+type PCIeDeviceData struct {
+	ID              *string            `json:"Id"`
+	Name            *string            `json:"Name"`
+	Manufacturer    *string            `json:"Manufacturer"`
+	Model           *string            `json:"Model"`
+	SerialNumber    *string            `json:"SerialNumber"`
+	PartNumber      *string            `json:"PartNumber"`
+	FirmwareVersion *string            `json:"FirmwareVersion"`
+	DeviceType      *string            `json:"DeviceType"`
+	PCIeInterface   *PCIeInterfaceData `json:"PCIeInterface"`
+	Slot            *PCIeSlotData      `json:"Slot"`
+	PCIeFunctions   *OData             `json:"PCIeFunctions"`
+	Links           *PCIeDeviceLinks   `json:"Links"`
+	Status          Status             `json:"Status"`
+	Oem             json.RawMessage    `json:"Oem"`
+	SelfEndpoint    *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// PCIeInterfaceData - PCIe interface of a device
+type PCIeInterfaceData struct {
+	PCIeType    *string `json:"PCIeType"`
+	MaxPCIeType *string `json:"MaxPCIeType"`
+	LanesInUse  *int    `json:"LanesInUse"`
+	MaxLanes    *int    `json:"MaxLanes"`
+}
+
+// PCIeSlotData - slot of a PCIe device
+type PCIeSlotData struct {
+	SlotType *string               `json:"SlotType"`
+	PCIeType *string               `json:"PCIeType"`
+	Lanes    *int                  `json:"Lanes"`
+	Location *PhysicalLocationData `json:"Location"`
+}
+
+// PCIeDeviceLinks - resources related to a PCIe device
+type PCIeDeviceLinks struct {
+	// PCIeFunctions - functions of the device, replaced by the PCIeFunctions collection in newer schema versions
+	PCIeFunctions []OData `json:"PCIeFunctions"`
+}
+
+// PCIeFunctionData - function of a PCIe device
+type PCIeFunctionData struct {
+	ID                *string `json:"Id"`
+	Name              *string `json:"Name"`
+	FunctionID        *int    `json:"FunctionId"`
+	FunctionType      *string `json:"FunctionType"`
+	DeviceClass       *string `json:"DeviceClass"`
+	VendorID          *string `json:"VendorId"`
+	DeviceID          *string `json:"DeviceId"`
+	SubsystemVendorID *string `json:"SubsystemVendorId"`
+	SubsystemID       *string `json:"SubsystemId"`
+	ClassCode         *string `json:"ClassCode"`
+	RevisionID        *string `json:"RevisionId"`
+	Status            Status  `json:"Status"`
+	SelfEndpoint      *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// NetworkAdapterData - network adapter of a chassis
+type NetworkAdapterData struct {
+	ID           *string                    `json:"Id"`
+	Name         *string                    `json:"Name"`
+	Manufacturer *string                    `json:"Manufacturer"`
+	Model        *string                    `json:"Model"`
+	SerialNumber *string                    `json:"SerialNumber"`
+	PartNumber   *string                    `json:"PartNumber"`
+	SKU          *string                    `json:"SKU"`
+	Controllers  []NetworkAdapterController `json:"Controllers"`
+	Ports        *OData                     `json:"Ports"`
+	NetworkPorts *OData                     `json:"NetworkPorts"`
+	Status       Status                     `json:"Status"`
+	Oem          json.RawMessage            `json:"Oem"`
+	SelfEndpoint *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// NetworkAdapterController - controller of a network adapter
+type NetworkAdapterController struct {
+	FirmwarePackageVersion *string                        `json:"FirmwarePackageVersion"`
+	Location               *PhysicalLocationData          `json:"Location"`
+	Links                  *NetworkAdapterControllerLinks `json:"Links"`
+}
+
+// NetworkAdapterControllerLinks - resources related to a network adapter controller
+type NetworkAdapterControllerLinks struct {
+	PCIeDevices []OData `json:"PCIeDevices"`
+}
+
+// NetworkPortData - port of a network adapter, either a Port or a (deprecated) NetworkPort resource
+type NetworkPortData struct {
+	ID                         *string               `json:"Id"`
+	Name                       *string               `json:"Name"`
+	PortID                     *string               `json:"PortId"`
+	PhysicalPortNumber         *string               `json:"PhysicalPortNumber"`
+	LinkStatus                 *string               `json:"LinkStatus"`
+	LinkState                  *string               `json:"LinkState"`
+	CurrentLinkSpeedMbps       *int                  `json:"CurrentLinkSpeedMbps"`
+	CurrentSpeedGbps           *float64              `json:"CurrentSpeedGbps"`
+	AssociatedNetworkAddresses []string              `json:"AssociatedNetworkAddresses"`
+	Ethernet                   *NetworkPortEthernet  `json:"Ethernet"`
+	Location                   *PhysicalLocationData `json:"Location"`
+	Status                     Status                `json:"Status"`
+	Oem                        json.RawMessage       `json:"Oem"`
+	SelfEndpoint               *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// NetworkPortEthernet - ethernet properties of a port
+type NetworkPortEthernet struct {
+	AssociatedMACAddresses []string `json:"AssociatedMACAddresses"`
+}
+
+// TrustedModuleData - trusted module (TPM) of a system
+type TrustedModuleData struct {
+	InterfaceType          *string `json:"InterfaceType"`
+	InterfaceTypeSelection *string `json:"InterfaceTypeSelection"`
+	FirmwareVersion        *string `json:"FirmwareVersion"`
+	FirmwareVersion2       *string `json:"FirmwareVersion2"`
+	Status                 Status  `json:"Status"`
+}
+
+// SecureBootData - UEFI Secure Boot state of a system
+type SecureBootData struct {
+	ID                    *string            `json:"Id"`
+	Name                  *string            `json:"Name"`
+	SecureBootEnable      *bool              `json:"SecureBootEnable"`
+	SecureBootMode        *string            `json:"SecureBootMode"`
+	SecureBootCurrentBoot *string            `json:"SecureBootCurrentBoot"`
+	SecureBootDatabases   *OData             `json:"SecureBootDatabases"`
+	Actions               *SecureBootActions `json:"Actions"`
+	Oem                   json.RawMessage    `json:"Oem"`
+	SelfEndpoint          *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// SecureBootActions - supported actions of the Secure Boot resource
+type SecureBootActions struct {
+	ResetKeys *SecureBootResetKeysAction `json:"#SecureBoot.ResetKeys"`
+}
+
+// SecureBootResetKeysAction - action to reset the Secure Boot keys
+type SecureBootResetKeysAction struct {
+	Target              string   `json:"target"`
+	ResetKeysTypeValues []string `json:"ResetKeysType@Redfish.AllowableValues"`
+}
+
+// SecureBootDatabaseData - UEFI Secure Boot key database (e.g. PK, KEK, db or dbx)
+type SecureBootDatabaseData struct {
+	ID           *string `json:"Id"`
+	Name         *string `json:"Name"`
+	DatabaseID   *string `json:"DatabaseId"`
+	Certificates *OData  `json:"Certificates"`
+	Signatures   *OData  `json:"Signatures"`
+	SelfEndpoint *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// CertificateData - certificate, e.g. of a Secure Boot key database
+type CertificateData struct {
+	ID              *string                `json:"Id"`
+	Name            *string                `json:"Name"`
+	CertificateType *string                `json:"CertificateType"`
+	Subject         *CertificateIdentifier `json:"Subject"`
+	Issuer          *CertificateIdentifier `json:"Issuer"`
+	SerialNumber    *string                `json:"SerialNumber"`
+	ValidNotBefore  *string                `json:"ValidNotBefore"`
+	ValidNotAfter   *string                `json:"ValidNotAfter"`
+	SelfEndpoint    *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
+}
+
+// CertificateIdentifier - subject or issuer of a certificate
+type CertificateIdentifier struct {
+	CommonName         *string `json:"CommonName"`
+	Organization       *string `json:"Organization"`
+	OrganizationalUnit *string `json:"OrganizationalUnit"`
+	Country            *string `json:"Country"`
+}
+
+// payload to enable or disable Secure Boot
+type secureBootEnablePayload struct {
+	SecureBootEnable bool `json:"SecureBootEnable"`
+}
+
+// payload to reset the Secure Boot keys
+type secureBootResetKeysPayload struct {
+	ResetKeysType string `json:"ResetKeysType"`
+}
+
 // ManagerLicenseData - license data for management board
 type ManagerLicenseData struct {
 	Name       string
@@ -338,6 +1075,7 @@ type ManagerData struct {
 	FirmwareVersion *string         `json:"FirmwareVersion"`
 	Oem             json.RawMessage `json:"Oem"`
 	Actions         json.RawMessage `json:"Actions"` // may contain vendor specific data and endpoints
+	LogServices     *OData          `json:"LogServices"`
 
 	/* futher data
 	   VirtualMedia
@@ -350,6 +1088,8 @@ type ManagerData struct {
 	*/
 
 	SelfEndpoint *string
+	// ETag - entity tag of the resource when it was read
+	ETag *string `json:"@odata.etag"`
 }
 
 // X509CertInfo - X509 certificate information
@@ -377,6 +1117,21 @@ type CSRData struct {
 	CN string // Common name
 }
 
+// payload for CSR generation, empty optional fields are omitted
+type csrPayload struct {
+	Country    string `json:"Country"`
+	State      string `json:"State,omitempty"`
+	City       string `json:"City,omitempty"`
+	OrgName    string `json:"OrgName,omitempty"`
+	OrgUnit    string `json:"OrgUnit,omitempty"`
+	CommonName string `json:"CommonName"`
+}
+
+// payload for certificate import
+type certificateImportPayload struct {
+	Certificate string `json:"Certificate"`
+}
+
 // AccountCreateData - data for account creation
 type AccountCreateData struct {
 	UserName string `json:",omitempty"`
@@ -391,7 +1146,28 @@ type AccountCreateData struct {
 	// Note: OemHpPrivilegeMap is an _internal_ struct but must be exported for json.Marshal !
 	//       Don't use this structm use HPEPrivileges instead
 	OemHpPrivilegeMap *AccountPrivilegeMapOemHp `json:",omitempty"`
-	HPEPrivileges     uint
+	HPEPrivileges     uint                      `json:"-"`
+
+	// ETag - optional entity tag of the account, the modification is rejected if the account has been changed
+	ETag string `json:"-"`
+}
+
+// payload for account creation on service processors supporting roles
+type accountCreatePayload struct {
+	UserName string `json:"UserName"`
+	Password string `json:"Password"`
+	RoleID   string `json:"RoleId"`
+}
+
+// payload for password change
+type accountPasswordPayload struct {
+	Password string `json:"Password"`
+}
+
+// payload for session login
+type loginPayload struct {
+	UserName string `json:"UserName"`
+	Password string `json:"Password"`
 }
 
 // Redfish vendor flavors
@@ -429,6 +1205,16 @@ var VendorCapabilities = map[string]uint{
 	"":           HasAccountService | HasSecurityService | HasAccountRoles | HasChassis,
 }
 
+// service processor quirks
+const (
+	QuirkNoConnectionReuse uint = 1 << iota // don't reuse TCP connections (e.g. HP iLO4 responds with EOF on reused connections)
+)
+
+// VendorQuirks - map quirks by vendor
+var VendorQuirks = map[string]uint{
+	"hp": QuirkNoConnectionReuse,
+}
+
 // HTTPResult - result of the http_request calls
 type HTTPResult struct {
 	URL        string
@@ -448,6 +1234,7 @@ type BaseRedfish interface {
 	MapSystensByID() (map[string]*SystemData, error)
 	MapSystemsByUUID() (map[string]*SystemData, error)
 	MapSystemsBySerialNumber() (map[string]*SystemData, error)
+	MapSystemsByMAC() (map[string]*SystemData, error)
 	GetAccounts() ([]string, error)
 	GetAccountData(string) (*AccountData, error)
 	MapAccountsByName() (map[string]*AccountData, error)
@@ -465,6 +1252,7 @@ type BaseRedfish interface {
 	ModifyAccount(string, AccountCreateData) error
 	DeleteAccount(string) error
 	ChangePassword(string, string) error
+	ChangePasswordIfMatch(string, string, string) error
 	SetSystemPowerState(*SystemData, string) error
 	ProcessError(HTTPResult) (*Error, error)
 	GetLicense(*ManagerData) (*ManagerLicenseData, error)
@@ -475,14 +1263,163 @@ type BaseRedfish interface {
 	GetManagerData(string) (*ManagerData, error)
 	MapManagersByID() (map[string]*ManagerData, error)
 	MapManagersByUUID() (map[string]*ManagerData, error)
+	GetResource(string) (map[string]interface{}, error)
+	GetResourceInto(string, interface{}) error
+	PatchResource(string, interface{}) (HTTPResult, error)
+	PatchResourceIfMatch(string, string, interface{}) (HTTPResult, error)
+	PostResource(string, interface{}) (HTTPResult, error)
+	PutResource(string, interface{}) (HTTPResult, error)
+	DeleteResource(string) (HTTPResult, error)
+	GetTaskStatus(*Task) (*TaskData, error)
+	GetCollectionMembers(string) ([]string, error)
+	GetResourceWithQuery(string, QueryOptions) (map[string]interface{}, error)
+	GetResourceIntoWithQuery(string, QueryOptions, interface{}) error
+	GetBIOSData(*SystemData) (*BIOSData, error)
+	GetBIOSPendingSettings(*BIOSData) (*BIOSData, error)
+	GetBIOSPendingChanges(*BIOSData) (map[string]BIOSPendingValue, error)
+	GetBIOSAttributeRegistry(*BIOSData) (*BIOSAttributeRegistry, error)
+	SetBIOSAttributes(*BIOSData, *BIOSAttributeRegistry, map[string]interface{}) error
+	SetSystemBootOverride(*SystemData, BootOverride) error
+	ClearSystemBootOverride(*SystemData) error
+	SetSystemBootOrder(*SystemData, []string) error
+	GetSystemBootOptions(*SystemData) ([]*BootOptionData, error)
+	WaitForSystemPowerState(*SystemData, string, time.Duration) error
+	PowerOffSystem(*SystemData, PowerOptions) error
+	PowerOnSystem(*SystemData, PowerOptions) error
+	PowerCycleSystem(*SystemData, PowerOptions) error
+	GetSystemProcessors(*SystemData) ([]*ProcessorData, error)
+	MapProcessorsByID(*SystemData) (map[string]*ProcessorData, error)
+	GetSystemMemory(*SystemData) ([]*MemoryData, error)
+	MapMemoryByID(*SystemData) (map[string]*MemoryData, error)
+	MapMemoryByDeviceLocator(*SystemData) (map[string]*MemoryData, error)
+	GetSystemStorage(*SystemData) ([]*StorageData, error)
+	MapStorageByID(*SystemData) (map[string]*StorageData, error)
+	GetSystemSimpleStorage(*SystemData) ([]*SimpleStorageData, error)
+	GetStorageDrives(*StorageData) ([]*DriveData, error)
+	GetStorageVolumes(*StorageData) ([]*VolumeData, error)
+	CreateVolume(*StorageData, VolumeCreateData) (*Task, error)
+	DeleteVolume(*VolumeData) (*Task, error)
+	SecureEraseDrive(*DriveData) (*Task, error)
+	GetSystemLogServices(*SystemData) ([]*LogServiceData, error)
+	GetManagerLogServices(*ManagerData) ([]*LogServiceData, error)
+	GetLogEntries(*LogServiceData, LogEntryFilter) ([]*LogEntryData, error)
+	FollowLogEntries(*LogServiceData, LogEntryFilter, time.Duration, func(*LogEntryData) error) error
+	ClearLog(*LogServiceData) error
+	GetSystemEthernetInterfaces(*SystemData) ([]*EthernetInterfaceData, error)
+	GetEthernetInterfaceVLANs(*EthernetInterfaceData) ([]*VLANNetworkInterfaceData, error)
+	GetSystemPCIeDevices(*SystemData) ([]*PCIeDeviceData, error)
+	GetChassisPCIeDevices(*ChassisData) ([]*PCIeDeviceData, error)
+	GetSystemPCIeFunctions(*SystemData) ([]*PCIeFunctionData, error)
+	GetPCIeFunctions(*PCIeDeviceData) ([]*PCIeFunctionData, error)
+	GetChassisNetworkAdapters(*ChassisData) ([]*NetworkAdapterData, error)
+	GetNetworkAdapterPorts(*NetworkAdapterData) ([]*NetworkPortData, error)
+	GetSystemSecureBoot(*SystemData) (*SecureBootData, error)
+	SetSecureBootEnable(*SecureBootData, bool) error
+	ResetSecureBootKeys(*SecureBootData, string) error
+	GetSecureBootDatabases(*SecureBootData) ([]*SecureBootDatabaseData, error)
+	GetSecureBootDatabaseCertificates(*SecureBootDatabaseData) ([]*CertificateData, error)
+	WaitForTask(*Task, time.Duration) (*TaskData, error)
+	SetSystemPowerStateTask(*SystemData, string) (*Task, error)
+	GenCSRTask(CSRData) (*Task, error)
 
-	httpRequest(string, string, *map[string]string, io.Reader, bool) (HTTPResult, error)
+	LoginContext(context.Context) error
+	LogoutContext(context.Context) error
+	GetSystemsContext(context.Context) ([]string, error)
+	GetSystemDataContext(context.Context, string) (*SystemData, error)
+	MapSystensByIDContext(context.Context) (map[string]*SystemData, error)
+	MapSystemsByUUIDContext(context.Context) (map[string]*SystemData, error)
+	MapSystemsBySerialNumberContext(context.Context) (map[string]*SystemData, error)
+	MapSystemsByMACContext(context.Context) (map[string]*SystemData, error)
+	GetAccountsContext(context.Context) ([]string, error)
+	GetAccountDataContext(context.Context, string) (*AccountData, error)
+	MapAccountsByNameContext(context.Context) (map[string]*AccountData, error)
+	MapAccountsByIDContext(context.Context) (map[string]*AccountData, error)
+	GetRolesContext(context.Context) ([]string, error)
+	GetRoleDataContext(context.Context, string) (*AccountData, error)
+	MapRolesByNameContext(context.Context) (map[string]*RoleData, error)
+	MapRolesByIDContext(context.Context) (map[string]*RoleData, error)
+	GenCSRContext(context.Context, CSRData) error
+	FetchCSRContext(context.Context) (string, error)
+	ImportCertificateContext(context.Context, string) error
+	ResetSPContext(context.Context) error
+	GetVendorFlavorContext(context.Context) error
+	AddAccountContext(context.Context, AccountCreateData) error
+	ModifyAccountContext(context.Context, string, AccountCreateData) error
+	DeleteAccountContext(context.Context, string) error
+	ChangePasswordContext(context.Context, string, string) error
+	ChangePasswordIfMatchContext(context.Context, string, string, string) error
+	SetSystemPowerStateContext(context.Context, *SystemData, string) error
+	GetLicenseContext(context.Context, *ManagerData) (*ManagerLicenseData, error)
+	GetManagersContext(context.Context) ([]string, error)
+	GetManagerDataContext(context.Context, string) (*ManagerData, error)
+	MapManagersByIDContext(context.Context) (map[string]*ManagerData, error)
+	MapManagersByUUIDContext(context.Context) (map[string]*ManagerData, error)
+	GetResourceContext(context.Context, string) (map[string]interface{}, error)
+	GetResourceIntoContext(context.Context, string, interface{}) error
+	PatchResourceContext(context.Context, string, interface{}) (HTTPResult, error)
+	PatchResourceIfMatchContext(context.Context, string, string, interface{}) (HTTPResult, error)
+	PostResourceContext(context.Context, string, interface{}) (HTTPResult, error)
+	PutResourceContext(context.Context, string, interface{}) (HTTPResult, error)
+	DeleteResourceContext(context.Context, string) (HTTPResult, error)
+	GetTaskStatusContext(context.Context, *Task) (*TaskData, error)
+	GetCollectionMembersContext(context.Context, string) ([]string, error)
+	GetResourceWithQueryContext(context.Context, string, QueryOptions) (map[string]interface{}, error)
+	GetResourceIntoWithQueryContext(context.Context, string, QueryOptions, interface{}) error
+	GetBIOSDataContext(context.Context, *SystemData) (*BIOSData, error)
+	GetBIOSPendingSettingsContext(context.Context, *BIOSData) (*BIOSData, error)
+	GetBIOSPendingChangesContext(context.Context, *BIOSData) (map[string]BIOSPendingValue, error)
+	GetBIOSAttributeRegistryContext(context.Context, *BIOSData) (*BIOSAttributeRegistry, error)
+	SetBIOSAttributesContext(context.Context, *BIOSData, *BIOSAttributeRegistry, map[string]interface{}) error
+	SetSystemBootOverrideContext(context.Context, *SystemData, BootOverride) error
+	ClearSystemBootOverrideContext(context.Context, *SystemData) error
+	SetSystemBootOrderContext(context.Context, *SystemData, []string) error
+	GetSystemBootOptionsContext(context.Context, *SystemData) ([]*BootOptionData, error)
+	WaitForSystemPowerStateContext(context.Context, *SystemData, string, time.Duration) error
+	PowerOffSystemContext(context.Context, *SystemData, PowerOptions) error
+	PowerOnSystemContext(context.Context, *SystemData, PowerOptions) error
+	PowerCycleSystemContext(context.Context, *SystemData, PowerOptions) error
+	GetSystemProcessorsContext(context.Context, *SystemData) ([]*ProcessorData, error)
+	MapProcessorsByIDContext(context.Context, *SystemData) (map[string]*ProcessorData, error)
+	GetSystemMemoryContext(context.Context, *SystemData) ([]*MemoryData, error)
+	MapMemoryByIDContext(context.Context, *SystemData) (map[string]*MemoryData, error)
+	MapMemoryByDeviceLocatorContext(context.Context, *SystemData) (map[string]*MemoryData, error)
+	GetSystemStorageContext(context.Context, *SystemData) ([]*StorageData, error)
+	MapStorageByIDContext(context.Context, *SystemData) (map[string]*StorageData, error)
+	GetSystemSimpleStorageContext(context.Context, *SystemData) ([]*SimpleStorageData, error)
+	GetStorageDrivesContext(context.Context, *StorageData) ([]*DriveData, error)
+	GetStorageVolumesContext(context.Context, *StorageData) ([]*VolumeData, error)
+	CreateVolumeContext(context.Context, *StorageData, VolumeCreateData) (*Task, error)
+	DeleteVolumeContext(context.Context, *VolumeData) (*Task, error)
+	SecureEraseDriveContext(context.Context, *DriveData) (*Task, error)
+	GetSystemLogServicesContext(context.Context, *SystemData) ([]*LogServiceData, error)
+	GetManagerLogServicesContext(context.Context, *ManagerData) ([]*LogServiceData, error)
+	GetLogEntriesContext(context.Context, *LogServiceData, LogEntryFilter) ([]*LogEntryData, error)
+	FollowLogEntriesContext(context.Context, *LogServiceData, LogEntryFilter, time.Duration, func(*LogEntryData) error) error
+	ClearLogContext(context.Context, *LogServiceData) error
+	GetSystemEthernetInterfacesContext(context.Context, *SystemData) ([]*EthernetInterfaceData, error)
+	GetEthernetInterfaceVLANsContext(context.Context, *EthernetInterfaceData) ([]*VLANNetworkInterfaceData, error)
+	GetSystemPCIeDevicesContext(context.Context, *SystemData) ([]*PCIeDeviceData, error)
+	GetChassisPCIeDevicesContext(context.Context, *ChassisData) ([]*PCIeDeviceData, error)
+	GetSystemPCIeFunctionsContext(context.Context, *SystemData) ([]*PCIeFunctionData, error)
+	GetPCIeFunctionsContext(context.Context, *PCIeDeviceData) ([]*PCIeFunctionData, error)
+	GetChassisNetworkAdaptersContext(context.Context, *ChassisData) ([]*NetworkAdapterData, error)
+	GetNetworkAdapterPortsContext(context.Context, *NetworkAdapterData) ([]*NetworkPortData, error)
+	GetSystemSecureBootContext(context.Context, *SystemData) (*SecureBootData, error)
+	SetSecureBootEnableContext(context.Context, *SecureBootData, bool) error
+	ResetSecureBootKeysContext(context.Context, *SecureBootData, string) error
+	GetSecureBootDatabasesContext(context.Context, *SecureBootData) ([]*SecureBootDatabaseData, error)
+	GetSecureBootDatabaseCertificatesContext(context.Context, *SecureBootDatabaseData) ([]*CertificateData, error)
+	WaitForTaskContext(context.Context, *Task, time.Duration) (*TaskData, error)
+	SetSystemPowerStateTaskContext(context.Context, *SystemData, string) (*Task, error)
+	GenCSRTaskContext(context.Context, CSRData) (*Task, error)
+
+	httpRequest(context.Context, string, string, *map[string]string, io.Reader, bool) (HTTPResult, error)
 	getCSRTarget_HP(*ManagerData) (string, error)
 	getCSRTarget_HPE(*ManagerData) (string, error)
 	getCSRTarget_Huawei(*ManagerData) (string, error)
-	makeCSRPayload(CSRData) string
-	makeCSRPayload_HP(CSRData) string
-	makeCSRPayload_Vanilla(CSRData) string
+	makeCSRPayload(CSRData) (string, error)
+	makeCSRPayload_HP(CSRData) (string, error)
+	makeCSRPayload_Vanilla(CSRData) (string, error)
 	fetchCSR_HP(*ManagerData) (string, error)
 	fetchCSR_HPE(*ManagerData) (string, error)
 	fetchCSR_Huawei(*ManagerData) (string, error)
@@ -490,10 +1427,13 @@ type BaseRedfish interface {
 	getImportCertTarget_HPE(*ManagerData) (string, error)
 	getImportCertTarget_Huawei(*ManagerData) (string, error)
 	makeAccountCreateModifyPayload(AccountCreateData) (string, error)
-	setAllowedResetTypes(*SystemData) error
+	makeAccountPayloadVanilla(AccountCreateData) (string, error)
+	makeLoginPayload() (string, error)
+	makeCertificateImportPayload(string) (string, error)
+	setAllowedResetTypes(context.Context, *SystemData) error
 	hpGetLicense(*ManagerData) (*ManagerLicenseData, error)
 	hpeGetLicense(*ManagerData) (*ManagerLicenseData, error)
-	hpHpePrepareLicensePayload([]byte) string
+	hpHpePrepareLicensePayload([]byte) (string, error)
 }
 
 // Redfish - object to access Redfish API
@@ -510,11 +1450,21 @@ type Redfish struct {
 	Verbose         bool
 	RawBaseContent  string
 
+	// CACertificates - certificate authorities to verify the server certificate, system pool is used if not set
+	CACertificates *x509.CertPool
+	// ClientCertificates - client certificates for certificate based authentication
+	ClientCertificates []tls.Certificate
+	// PinStore - if set, the server certificate is verified against the pinned SHA256 fingerprint instead of
+	// the certificate chain. If no fingerprint was pinned for host and port ("host:port"), the certificate is
+	// pinned on first use. Ignored if CACertificates is set, the chain and hostname are verified instead.
+	PinStore PinStore
+
 	// endpoints
 	AccountService string
 	Chassis        string
 	Managers       string
 	SessionService string
+	Registries     string
 	Sessions       string
 	Systems        string
 
@@ -522,5 +1472,58 @@ type Redfish struct {
 	Flavor       uint
 	FlavorString string
 
+	// ProtocolFeatures - optional protocol features as reported by the service root, nil if not reported
+	ProtocolFeatures *ProtocolFeaturesSupported
+
+	// HTTPClient - optional HTTP client to use for all requests (redirects will never be followed)
+	// Note: The TLS settings InsecureSSL, CACertificates, ClientCertificates and PinStore are NOT applied to
+	//       this client, TLS must be configured in it's transport
+	HTTPClient *http.Client
+	// Transport - optional transport for the HTTP client, ignored if HTTPClient is set
+	// Note: The TLS settings InsecureSSL, CACertificates, ClientCertificates and PinStore are NOT applied to
+	//       this transport, they only apply to the internal transport
+	Transport http.RoundTripper
+
+	// RetryPolicy - retry policy for transient errors, DefaultRetryPolicy (GET and HEAD only) is used if not set.
+	// Set it to &RetryPolicy{MaxAttempts: 1} to disable retries.
+	RetryPolicy *RetryPolicy
+
+	// SessionTimeout - session timeout as reported by the session service
+	SessionTimeout time.Duration
+	// UseBasicAuth - authenticate every request by HTTP basic authentication instead of creating a session
+	UseBasicAuth bool
+	// DisableReLogin - don't re-establish the session if a request is rejected as unauthorized (e.g. expired session)
+	DisableReLogin bool
+
+	// MaxConcurrentFetches - number of collection members fetched in parallel by the Map functions, 0 or 1 to fetch
+	// one member after another
+	MaxConcurrentFetches int
+	// MaxConcurrentRequests - maximal number of concurrent requests to the service processor, 0 for no limit
+	MaxConcurrentRequests int
+
+	// Logger - optional logger for verbose and debug output, a private logrus logger is used if not set
+	Logger Logger
+
 	initialised bool
+
+	// quirks detected from the service root, applied before the vendor flavor is known
+	serviceRootQuirks uint
+
+	// private logger, used if Logger is not set
+	defaultLogger *log.Logger
+	loggerLock    sync.Mutex
+
+	// HTTP client, created on first use and reused for subsequent requests
+	httpClient     *http.Client
+	httpClientLock sync.Mutex
+
+	// limit of concurrent requests, created on first use
+	requestSemaphore     chan struct{}
+	requestSemaphoreLock sync.Mutex
+
+	// session handling
+	authLock        sync.RWMutex
+	reLoginLock     sync.Mutex
+	keepAliveLock   sync.Mutex
+	keepAliveCancel context.CancelFunc
 }
@@ -92,6 +92,27 @@ type HTTPSCertDataOemHp struct {
 	Actions HTTPSCertActionsOemHp `json:"Actions"`
 }
 
+// payload for license installation on HP(E)
+type licensePayloadHpHpe struct {
+	LicenseKey string `json:"LicenseKey"`
+}
+
+// payload for account creation and modification on HP(E), privileges are set in the Oem section
+type accountCreateModifyPayloadHp struct {
+	UserName string                          `json:",omitempty"`
+	Password string                          `json:",omitempty"`
+	Oem      accountCreateModifyPayloadOemHp `json:"Oem"`
+}
+
+type accountCreateModifyPayloadOemHp struct {
+	Hp accountCreateModifyPayloadOemHpData `json:"Hp"`
+}
+
+type accountCreateModifyPayloadOemHpData struct {
+	LoginName  string                    `json:",omitempty"`
+	Privileges *AccountPrivilegeMapOemHp `json:",omitempty"`
+}
+
 // AccountPrivilegeMapOemHp - HP(E) uses it's own privilege map instead of roles
 type AccountPrivilegeMapOemHp struct {
 	Login                bool `json:"LoginPriv"`
@@ -2,9 +2,110 @@ package redfish
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"strings"
 )
 
+// ErrNotAuthenticated - no session has been established or no credentials for HTTP basic authentication are set
+var ErrNotAuthenticated = errors.New("No authentication token found, is the session setup correctly?")
+
+// ErrNotSupported - operation is not supported by the service processor, use errors.Is to check for it
+var ErrNotSupported = errors.New("Operation is not supported for this vendor")
+
+// ErrPreconditionFailed - conditional request was rejected because the resource has been modified since it was read,
+// use errors.Is to check for it
+var ErrPreconditionFailed = errors.New("Resource has been modified, precondition failed")
+
+// NotSupportedError - operation is not supported by the vendor of the service processor
+type NotSupportedError struct {
+	Flavor  string
+	Message string
+}
+
+func (e *NotSupportedError) Error() string {
+	return e.Message
+}
+
+// Is - allow errors.Is(err, ErrNotSupported)
+func (e *NotSupportedError) Is(target error) bool {
+	return target == ErrNotSupported
+}
+
+func (r *Redfish) newNotSupportedError(msg string) error {
+	return &NotSupportedError{
+		Flavor:  r.FlavorString,
+		Message: msg,
+	}
+}
+
+// HTTPError - HTTP request returned an unexpected status code
+type HTTPError struct {
+	Method string
+	// Expected - expected HTTP status codes
+	Expected []int
+	Result   HTTPResult
+	// RedfishError - error object returned by the service processor, nil if the reply didn't contain one
+	RedfishError *Error
+}
+
+func (e *HTTPError) Error() string {
+	var expected = make([]string, 0)
+
+	msg := fmt.Sprintf("HTTP %s for %s returned \"%s\"", e.Method, e.Result.URL, e.Result.Status)
+
+	for _, code := range e.Expected {
+		expected = append(expected, fmt.Sprintf("\"%d %s\"", code, http.StatusText(code)))
+	}
+
+	switch len(expected) {
+	case 0:
+	case 1:
+		msg += " instead of " + expected[0]
+	default:
+		msg += " instead of " + strings.Join(expected[:len(expected)-1], ", ") + " or " + expected[len(expected)-1]
+	}
+
+	if e.RedfishError != nil {
+		rmsg := errorMessage(e.RedfishError)
+		if rmsg == "" && e.RedfishError.Error.Message != nil {
+			rmsg = *e.RedfishError.Error.Message
+		}
+		if rmsg != "" {
+			msg += ": " + rmsg
+		}
+	}
+
+	return msg
+}
+
+// Is - allow errors.Is(err, ErrPreconditionFailed) for "412 Precondition Failed"
+func (e *HTTPError) Is(target error) bool {
+	return target == ErrPreconditionFailed && e.Result.StatusCode == http.StatusPreconditionFailed
+}
+
+// StatusCode - HTTP status code returned by the service processor
+func (e *HTTPError) StatusCode() int {
+	return e.Result.StatusCode
+}
+
+func (r *Redfish) newHTTPError(method string, response HTTPResult, expected ...int) error {
+	var result = &HTTPError{
+		Method:   method,
+		Expected: expected,
+		Result:   response,
+	}
+
+	// not all service processors return an error object
+	rerr, err := r.ProcessError(response)
+	if err == nil && (rerr.Error.Code != nil || rerr.Error.Message != nil) {
+		result.RedfishError = rerr
+	}
+
+	return result
+}
+
 // ProcessError - processess error response from API
 func (r *Redfish) ProcessError(response HTTPResult) (*Error, error) {
 	var rerr Error
@@ -20,6 +121,10 @@ func (r *Redfish) ProcessError(response HTTPResult) (*Error, error) {
 
 // GetErrorMessage - get error messages from error structure
 func (r *Redfish) GetErrorMessage(rerr *Error) string {
+	return errorMessage(rerr)
+}
+
+func errorMessage(rerr *Error) string {
 	var result string
 	var _list = make([]string, 0)
 
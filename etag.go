@@ -0,0 +1,27 @@
+package redfish
+
+// get entity tag of a resource, the ETag header takes precedence over @odata.etag from the content
+func etagFromResult(response HTTPResult, etag *string) *string {
+	if response.Header != nil {
+		hdr := response.Header.Get("ETag")
+		if hdr != "" {
+			return &hdr
+		}
+	}
+
+	if etag != nil && *etag == "" {
+		return nil
+	}
+	return etag
+}
+
+// additional headers for conditional requests, nil if the entity tag is not known
+func ifMatchHeader(etag string) *map[string]string {
+	if etag == "" {
+		return nil
+	}
+
+	return &map[string]string{
+		"If-Match": etag,
+	}
+}
@@ -0,0 +1,88 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// normalize MAC address to lowercase, colon separated notation
+func normalizeMACAddress(mac string) string {
+	return strings.Replace(strings.ToLower(strings.TrimSpace(mac)), "-", ":", -1)
+}
+
+// GetSystemEthernetInterfaces - get network interfaces of a system
+func (r *Redfish) GetSystemEthernetInterfaces(sd *SystemData) ([]*EthernetInterfaceData, error) {
+	return r.GetSystemEthernetInterfacesContext(context.Background(), sd)
+}
+
+// GetSystemEthernetInterfacesContext - same as GetSystemEthernetInterfaces but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemEthernetInterfacesContext(ctx context.Context, sd *SystemData) ([]*EthernetInterfaceData, error) {
+	var result = make([]*EthernetInterfaceData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	if sd.EthernetInterfaces == nil || sd.EthernetInterfaces.ID == nil || *sd.EthernetInterfaces.ID == "" {
+		return result, r.newNotSupportedError("System does not provide network interfaces")
+	}
+
+	members, err := r.getCollectionContent(ctx, *sd.EthernetInterfaces.ID)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d EthernetInterfaceData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// GetEthernetInterfaceVLANs - get VLANs of a network interface supporting multiple VLANs
+func (r *Redfish) GetEthernetInterfaceVLANs(nic *EthernetInterfaceData) ([]*VLANNetworkInterfaceData, error) {
+	return r.GetEthernetInterfaceVLANsContext(context.Background(), nic)
+}
+
+// GetEthernetInterfaceVLANsContext - same as GetEthernetInterfaceVLANs but uses the supplied context for all HTTP requests
+func (r *Redfish) GetEthernetInterfaceVLANsContext(ctx context.Context, nic *EthernetInterfaceData) ([]*VLANNetworkInterfaceData, error) {
+	var result = make([]*VLANNetworkInterfaceData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	if nic.VLANs == nil || nic.VLANs.ID == nil || *nic.VLANs.ID == "" {
+		return result, r.newNotSupportedError("Network interface does not provide a VLAN collection")
+	}
+
+	members, err := r.getCollectionContent(ctx, *nic.VLANs.ID)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d VLANNetworkInterfaceData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
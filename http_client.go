@@ -0,0 +1,84 @@
+package redfish
+
+import (
+	"net/http"
+)
+
+// non-GET methods (like PATCH, POST, ...) may or may not work when encountering
+// HTTP redirect. Don't follow 301/302. The new location can be checked by looking
+// at the "Location" header.
+func noRedirect(httpRequest *http.Request, httpVia []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+func (r *Redfish) newTransport() *http.Transport {
+	// start with the settings of the default transport to honour proxy settings from the environment,
+	// dial timeouts and keep-alive settings
+	transp := http.DefaultTransport.(*http.Transport).Clone()
+
+	transp.TLSClientConfig = r.tlsConfig()
+
+	return transp
+}
+
+// getHTTPClient - get HTTP client for this Redfish object. The client is created on first use and reused for
+// all subsequent requests to allow connection keep-alive
+func (r *Redfish) getHTTPClient() *http.Client {
+	r.httpClientLock.Lock()
+	defer r.httpClientLock.Unlock()
+
+	if r.httpClient != nil {
+		return r.httpClient
+	}
+
+	// use a copy of the supplied client because we must not follow redirects
+	if r.HTTPClient != nil {
+		client := *r.HTTPClient
+		client.CheckRedirect = noRedirect
+		if client.Timeout == 0 {
+			client.Timeout = r.Timeout
+		}
+		r.httpClient = &client
+		return r.httpClient
+	}
+
+	transp := r.Transport
+	if transp == nil {
+		transp = r.newTransport()
+	}
+
+	r.httpClient = &http.Client{
+		Timeout:       r.Timeout,
+		Transport:     transp,
+		CheckRedirect: noRedirect,
+	}
+	return r.httpClient
+}
+
+// ResetHTTPClient - discard the HTTP client (and it's idle connections). A new client will be created
+// on the next request, e.g. after changing TLS settings, Timeout, Transport or HTTPClient
+func (r *Redfish) ResetHTTPClient() {
+	r.httpClientLock.Lock()
+	defer r.httpClientLock.Unlock()
+
+	if r.httpClient != nil && r.HTTPClient == nil && r.Transport == nil {
+		r.httpClient.CloseIdleConnections()
+	}
+	r.httpClient = nil
+}
+
+// close idle connections of the HTTP client, connections of a supplied HTTPClient or Transport are left alone
+// because they may be shared with other users
+func (r *Redfish) closeIdleConnections() {
+	r.httpClientLock.Lock()
+	defer r.httpClientLock.Unlock()
+
+	if r.httpClient != nil && r.HTTPClient == nil && r.Transport == nil {
+		r.httpClient.CloseIdleConnections()
+	}
+}
+
+// check if the vendor flavor (or the service root if the flavor is not known yet) requires special handling
+func (r *Redfish) hasQuirk(q uint) bool {
+	return (VendorQuirks[r.FlavorString]|r.serviceRootQuirks)&q == q
+}
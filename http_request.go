@@ -1,42 +1,123 @@
 package redfish
 
 import (
-	"crypto/tls"
+	"bytes"
+	"context"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 )
 
-func (r *Redfish) httpRequest(endpoint string, method string, header *map[string]string, reader io.Reader, basicAuth bool) (HTTPResult, error) {
-	var result HTTPResult
-	var transp *http.Transport
-	var url string
+func (r *Redfish) httpRequest(ctx context.Context, endpoint string, method string, header *map[string]string, reader io.Reader, basicAuth bool) (HTTPResult, error) {
+	var payload []byte
+	var err error
 
-	if r.InsecureSSL {
-		transp = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	// the payload must be available for retries and replays
+	if reader != nil {
+		payload, err = ioutil.ReadAll(reader)
+		if err != nil {
+			return HTTPResult{}, err
 		}
-		if r.Debug {
-			log.Debug("Disabling verification of SSL certificates")
+	}
+
+	token := r.getAuthToken()
+
+	result, err := r.httpRequestRetry(ctx, endpoint, method, header, payload, basicAuth)
+	if err != nil || result.StatusCode != http.StatusUnauthorized {
+		return result, err
+	}
+
+	// session may have expired, login again and replay the request
+	if basicAuth || r.DisableReLogin || token == "" {
+		return result, err
+	}
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":      r.Hostname,
+			"port":          r.Port,
+			"timeout":       r.Timeout,
+			"flavor":        r.Flavor,
+			"flavor_string": r.FlavorString,
+			"method":        method,
+			"url":           result.URL,
+			"status":        result.Status,
+		}).Info("Request was rejected as unauthorized, re-establishing session")
+	}
+
+	err = r.reLogin(ctx, token)
+	if err != nil {
+		return result, err
+	}
+
+	return r.httpRequestRetry(ctx, endpoint, method, header, payload, basicAuth)
+}
+
+func (r *Redfish) httpRequestRetry(ctx context.Context, endpoint string, method string, header *map[string]string, payload []byte, basicAuth bool) (HTTPResult, error) {
+	policy := r.retryPolicy()
+
+	attempts := 1
+	if policy.appliesTo(method) {
+		attempts = policy.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		release, err := r.acquireRequestSlot(ctx)
+		if err != nil {
+			return HTTPResult{}, err
 		}
-	} else {
-		transp = &http.Transport{
-			TLSClientConfig: &tls.Config{},
+
+		result, err := r.doHTTPRequest(ctx, endpoint, method, header, payload, basicAuth)
+		release()
+
+		if attempt >= attempts || ctx.Err() != nil {
+			return result, err
+		}
+
+		if err != nil {
+			if !retryError(err) {
+				return result, err
+			}
+		} else if !policy.retryStatus(result.StatusCode) {
+			return result, nil
+		}
+
+		delay := policy.backoff(attempt, result)
+		if r.Verbose {
+			r.logger().WithFields(LogFields{
+				"hostname":      r.Hostname,
+				"port":          r.Port,
+				"timeout":       r.Timeout,
+				"flavor":        r.Flavor,
+				"flavor_string": r.FlavorString,
+				"method":        method,
+				"url":           result.URL,
+				"status":        result.Status,
+				"error":         err,
+				"attempt":       attempt,
+				"max_attempts":  attempts,
+				"delay":         delay,
+			}).Info("Transient error, retrying HTTP request")
 		}
-	}
 
-	client := &http.Client{
-		Timeout:   r.Timeout,
-		Transport: transp,
-		// non-GET methods (like PATCH, POST, ...) may or may not work when encountering
-		// HTTP redirect. Don't follow 301/302. The new location can be checked by looking
-		// at the "Location" header.
-		CheckRedirect: func(http_request *http.Request, http_via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, ctx.Err()
+		case <-timer.C:
+		}
 	}
+}
+
+func (r *Redfish) doHTTPRequest(ctx context.Context, endpoint string, method string, header *map[string]string, payload []byte, basicAuth bool) (HTTPResult, error) {
+	var result HTTPResult
+	var url string
+	var reader io.Reader
+
+	client := r.getHTTPClient()
 
 	if r.Port > 0 && r.Port != 443 {
 		// check if it is an endpoint or a full URL
@@ -57,7 +138,7 @@ func (r *Redfish) httpRequest(endpoint string, method string, header *map[string
 	result.URL = url
 
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":      r.Hostname,
 			"port":          r.Port,
 			"timeout":       r.Timeout,
@@ -65,11 +146,15 @@ func (r *Redfish) httpRequest(endpoint string, method string, header *map[string
 			"flavor_string": r.FlavorString,
 			"method":        method,
 			"url":           url,
-			"reader":        reader,
+			"payload":       redactPayload(string(payload)),
 		}).Debug("Sending HTTP request")
 	}
 
-	request, err := http.NewRequest(method, url, reader)
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, reader)
 	if err != nil {
 		return result, err
 	}
@@ -81,9 +166,9 @@ func (r *Redfish) httpRequest(endpoint string, method string, header *map[string
 		}
 	}()
 
-	if basicAuth {
+	if basicAuth || r.UseBasicAuth {
 		if r.Debug {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":      r.Hostname,
 				"port":          r.Port,
 				"timeout":       r.Timeout,
@@ -105,13 +190,16 @@ func (r *Redfish) httpRequest(endpoint string, method string, header *map[string
 	request.Header.Set("User-Agent", userAgent)
 
 	// add authentication token if present
-	if r.AuthToken != nil && *r.AuthToken != "" {
-		request.Header.Add("X-Auth-Token", *r.AuthToken)
+	token := r.getAuthToken()
+	if token != "" && !r.UseBasicAuth {
+		request.Header.Add("X-Auth-Token", token)
 	}
 
 	// close connection after response and prevent re-use of TCP connection because some implementations (e.g. HP iLO4)
 	// don't like connection reuse and respond with EoF for the next connections
-	request.Close = true
+	if r.hasQuirk(QuirkNoConnectionReuse) {
+		request.Close = true
+	}
 
 	// add supplied additional headers
 	if header != nil {
@@ -121,7 +209,7 @@ func (r *Redfish) httpRequest(endpoint string, method string, header *map[string
 	}
 
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":      r.Hostname,
 			"port":          r.Port,
 			"timeout":       r.Timeout,
@@ -129,7 +217,7 @@ func (r *Redfish) httpRequest(endpoint string, method string, header *map[string
 			"flavor_string": r.FlavorString,
 			"method":        method,
 			"url":           url,
-			"http_headers":  request.Header,
+			"http_headers":  redactHeader(request.Header),
 		}).Debug("HTTP request headers")
 	}
 
@@ -144,7 +232,7 @@ func (r *Redfish) httpRequest(endpoint string, method string, header *map[string
 	}()
 
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":      r.Hostname,
 			"port":          r.Port,
 			"timeout":       r.Timeout,
@@ -153,7 +241,7 @@ func (r *Redfish) httpRequest(endpoint string, method string, header *map[string
 			"method":        method,
 			"url":           url,
 			"status":        response.Status,
-			"http_headers":  response.Header,
+			"http_headers":  redactHeader(response.Header),
 		}).Debug("HTTP reply received")
 	}
 
@@ -166,7 +254,7 @@ func (r *Redfish) httpRequest(endpoint string, method string, header *map[string
 	}
 
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":      r.Hostname,
 			"port":          r.Port,
 			"timeout":       r.Timeout,
@@ -174,7 +262,7 @@ func (r *Redfish) httpRequest(endpoint string, method string, header *map[string
 			"flavor_string": r.FlavorString,
 			"method":        method,
 			"url":           url,
-			"content":       string(result.Content),
+			"content":       redactPayload(string(result.Content)),
 		}).Debug("Received content")
 	}
 
@@ -1,35 +1,27 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net"
 	"net/http"
 	"net/url"
 	"strings"
-	"time"
 )
 
 // Initialise Redfish basic data
 func (r *Redfish) Initialise() error {
+	return r.InitialiseContext(context.Background())
+}
+
+// InitialiseContext - same as Initialise but uses the supplied context for all HTTP requests
+func (r *Redfish) InitialiseContext(ctx context.Context) error {
 	var base baseEndpoint
 	var raw []byte
 
-	if r.Debug || r.Verbose {
-		// Logging setup
-		var logFmt = new(log.TextFormatter)
-		logFmt.FullTimestamp = true
-		logFmt.TimestampFormat = time.RFC3339
-		log.SetFormatter(logFmt)
-	}
-
-	if r.Debug {
-		log.SetLevel(log.DebugLevel)
-	}
-
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -38,10 +30,10 @@ func (r *Redfish) Initialise() error {
 			"path":               "/redfish/v1/",
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting basic information")
 	}
-	response, err := r.httpRequest("/redfish/v1/", "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, "/redfish/v1/", "GET", nil, nil, false)
 	if err != nil {
 		return err
 	}
@@ -57,7 +49,7 @@ func (r *Redfish) Initialise() error {
 		location := response.Header.Get("Location")
 
 		if r.Verbose {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":           r.Hostname,
 				"port":               r.Port,
 				"timeout":            r.Timeout,
@@ -66,7 +58,7 @@ func (r *Redfish) Initialise() error {
 				"path":               "/redfish/v1/",
 				"method":             "GET",
 				"additional_headers": nil,
-				"use_basic_auth":     false,
+				"use_basic_auth":     r.UseBasicAuth,
 				"status_code":        response.StatusCode,
 				"status":             response.Status,
 				"location":           location,
@@ -76,19 +68,7 @@ func (r *Redfish) Initialise() error {
 		// Note: Although RFC 2616 specify "The new permanent URI SHOULD be given by the Location field in the response."
 		//       we will barf because we have no way to obtain the redirect URL.
 		if location == "" {
-			log.WithFields(log.Fields{
-				"hostname":           r.Hostname,
-				"port":               r.Port,
-				"timeout":            r.Timeout,
-				"flavor":             r.Flavor,
-				"flavor_string":      r.FlavorString,
-				"path":               "/redfish/v1/",
-				"method":             "GET",
-				"additional_headers": nil,
-				"use_basic_auth":     false,
-				"status_code":        response.StatusCode,
-				"status":             response.Status,
-			}).Fatal("HTTP request returned 3xx status but didn't set new Location header")
+			return fmt.Errorf("BUG: HTTP request to %s returned \"%s\" but didn't set new Location header", response.URL, response.Status)
 		}
 
 		newLoc, err := url.Parse(location)
@@ -108,7 +88,7 @@ func (r *Redfish) Initialise() error {
 
 			r.Port = newPort
 			if r.Verbose {
-				log.WithFields(log.Fields{
+				r.logger().WithFields(LogFields{
 					"hostname":      r.Hostname,
 					"port":          r.Port,
 					"timeout":       r.Timeout,
@@ -126,7 +106,7 @@ func (r *Redfish) Initialise() error {
 			}
 
 			if newHost != strings.ToLower(r.Hostname) {
-				log.WithFields(log.Fields{
+				r.logger().WithFields(LogFields{
 					"hostname":           r.Hostname,
 					"port":               r.Port,
 					"timeout":            r.Timeout,
@@ -135,7 +115,7 @@ func (r *Redfish) Initialise() error {
 					"path":               "/redfish/v1/",
 					"method":             "GET",
 					"additional_headers": nil,
-					"use_basic_auth":     false,
+					"use_basic_auth":     r.UseBasicAuth,
 					"status_code":        response.StatusCode,
 					"status":             response.Status,
 					"location":           location,
@@ -145,7 +125,7 @@ func (r *Redfish) Initialise() error {
 
 		// Re-request base information from new location
 		if r.Verbose {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":           r.Hostname,
 				"port":               r.Port,
 				"timeout":            r.Timeout,
@@ -154,10 +134,10 @@ func (r *Redfish) Initialise() error {
 				"path":               "/redfish/v1/",
 				"method":             "GET",
 				"additional_headers": nil,
-				"use_basic_auth":     false,
+				"use_basic_auth":     r.UseBasicAuth,
 			}).Info("Rerequesting basic information")
 		}
-		response, err := r.httpRequest("/redfish/v1/", "GET", nil, nil, false)
+		response, err := r.httpRequest(ctx, "/redfish/v1/", "GET", nil, nil, false)
 		if err != nil {
 			return err
 		}
@@ -165,7 +145,7 @@ func (r *Redfish) Initialise() error {
 		raw = response.Content
 		r.RawBaseContent = string(raw)
 	} else if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &base)
@@ -206,6 +186,26 @@ func (r *Redfish) Initialise() error {
 	}
 	r.Systems = *base.Systems.ID
 
+	// the message and attribute registries are optional
+	if base.Registries.ID != nil {
+		r.Registries = *base.Registries.ID
+	}
+
+	r.ProtocolFeatures = base.ProtocolFeaturesSupported
+
+	// HP iLO4 reports Oem.Hp in the service root, apply its quirks before the vendor flavor is known
+	// because it responds with EOF on reused connections
+	r.serviceRootQuirks = 0
+	_, found := base.Oem["Hp"]
+	if found {
+		r.serviceRootQuirks = VendorQuirks["hp"]
+
+		// the connection used to fetch the service root is already back in the idle pool
+		if r.hasQuirk(QuirkNoConnectionReuse) {
+			r.closeIdleConnections()
+		}
+	}
+
 	r.initialised = true
 
 	return nil
@@ -1,10 +1,9 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 	"strings"
 )
@@ -77,12 +76,17 @@ func (r *Redfish) hpeGetLicense(mgr *ManagerData) (*ManagerLicenseData, error) {
 
 // GetLicense - get licenses of management board
 func (r *Redfish) GetLicense(mgr *ManagerData) (*ManagerLicenseData, error) {
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return nil, errors.New("No authentication token found, is the session setup correctly?")
+	return r.GetLicenseContext(context.Background(), mgr)
+}
+
+// GetLicenseContext - same as GetLicense but uses the supplied context for all HTTP requests
+func (r *Redfish) GetLicenseContext(ctx context.Context, mgr *ManagerData) (*ManagerLicenseData, error) {
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
 	}
 
 	if r.Flavor == RedfishFlavorNotInitialized {
-		err := r.GetVendorFlavor()
+		err := r.GetVendorFlavorContext(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -94,14 +98,18 @@ func (r *Redfish) GetLicense(mgr *ManagerData) (*ManagerLicenseData, error) {
 		return r.hpeGetLicense(mgr)
 	}
 
-	return nil, errors.New("License operations are not supported for this vendor. If this vendor supports license operations please file a feature request")
+	return nil, r.newNotSupportedError("License operations are not supported for this vendor. If this vendor supports license operations please file a feature request")
 }
 
-func (r *Redfish) hpHpePrepareLicensePayload(l []byte) string {
-	return fmt.Sprintf(`{ "LicenseKey": "%s" }`, string(l))
+func (r *Redfish) hpHpePrepareLicensePayload(l []byte) (string, error) {
+	raw, err := json.Marshal(licensePayloadHpHpe{LicenseKey: string(l)})
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
 }
 
-func (r *Redfish) hpSetLicense(mgr *ManagerData, l []byte) error {
+func (r *Redfish) hpSetLicense(ctx context.Context, mgr *ManagerData, l []byte) error {
 	var m ManagerDataOemHp
 
 	err := json.Unmarshal(mgr.Oem, &m)
@@ -114,10 +122,13 @@ func (r *Redfish) hpSetLicense(mgr *ManagerData, l []byte) error {
 		return fmt.Errorf("BUG: Expected LicenseService endpoint definition in .Oem.Hp.Links for vendor %s, but found none", r.FlavorString)
 	}
 
-	licensePayload := r.hpHpePrepareLicensePayload(l)
+	licensePayload, err := r.hpHpePrepareLicensePayload(l)
+	if err != nil {
+		return err
+	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -126,11 +137,11 @@ func (r *Redfish) hpSetLicense(mgr *ManagerData, l []byte) error {
 			"path":               *m.Hp.Links.LicenseService.ID,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Uploading license")
 	}
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -139,31 +150,23 @@ func (r *Redfish) hpSetLicense(mgr *ManagerData, l []byte) error {
 			"path":               *m.Hp.Links.LicenseService.ID,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
-			"payload":            licensePayload,
+			"use_basic_auth":     r.UseBasicAuth,
+			"payload":            redactPayload(licensePayload),
 		}).Debug("Uploading license")
 	}
 
-	response, err := r.httpRequest(*m.Hp.Links.LicenseService.ID, "POST", nil, strings.NewReader(licensePayload), false)
+	response, err := r.httpRequest(ctx, *m.Hp.Links.LicenseService.ID, "POST", nil, strings.NewReader(licensePayload), false)
 	if err != nil {
 		return err
 	}
 
 	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
-		redfishError, err := r.ProcessError(response)
-		if err != nil {
-			return fmt.Errorf("License installation returned \"%s\" instead of \"200 OK\" or \"201 Created\" and didn't return an error object", response.Status)
-		}
-		msg := r.GetErrorMessage(redfishError)
-		if msg != "" {
-			return fmt.Errorf("License installation failed: %s", msg)
-		}
-		return fmt.Errorf("License installation returned \"%s\" instead of \"200 OK\" or \"201 Created\" and didn't return an error object", response.Status)
+		return fmt.Errorf("License installation failed: %w", r.newHTTPError("POST", response, http.StatusOK, http.StatusCreated))
 	}
 	return nil
 }
 
-func (r *Redfish) hpeSetLicense(mgr *ManagerData, l []byte) error {
+func (r *Redfish) hpeSetLicense(ctx context.Context, mgr *ManagerData, l []byte) error {
 	var m ManagerDataOemHpe
 
 	err := json.Unmarshal(mgr.Oem, &m)
@@ -176,10 +179,13 @@ func (r *Redfish) hpeSetLicense(mgr *ManagerData, l []byte) error {
 		return fmt.Errorf("BUG: Expected LicenseService endpoint definition in .Oem.Hpe.Links for vendor %s, but found none", r.FlavorString)
 	}
 
-	licensePayload := r.hpHpePrepareLicensePayload(l)
+	licensePayload, err := r.hpHpePrepareLicensePayload(l)
+	if err != nil {
+		return err
+	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -188,11 +194,11 @@ func (r *Redfish) hpeSetLicense(mgr *ManagerData, l []byte) error {
 			"path":               *m.Hpe.Links.LicenseService.ID,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Uploading license")
 	}
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -201,26 +207,18 @@ func (r *Redfish) hpeSetLicense(mgr *ManagerData, l []byte) error {
 			"path":               *m.Hpe.Links.LicenseService.ID,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
-			"payload":            licensePayload,
+			"use_basic_auth":     r.UseBasicAuth,
+			"payload":            redactPayload(licensePayload),
 		}).Debug("Uploading license")
 	}
 
-	response, err := r.httpRequest(*m.Hpe.Links.LicenseService.ID, "POST", nil, strings.NewReader(licensePayload), false)
+	response, err := r.httpRequest(ctx, *m.Hpe.Links.LicenseService.ID, "POST", nil, strings.NewReader(licensePayload), false)
 	if err != nil {
 		return err
 	}
 
 	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
-		redfishError, err := r.ProcessError(response)
-		if err != nil {
-			return fmt.Errorf("License installation returned \"%s\" instead of \"200 OK\" or \"201 Created\" and didn't return an error object", response.Status)
-		}
-		msg := r.GetErrorMessage(redfishError)
-		if msg != "" {
-			return fmt.Errorf("License installation failed: %s", msg)
-		}
-		return fmt.Errorf("License installation returned \"%s\" instead of \"200 OK\" or \"201 Created\" and didn't return an error object", response.Status)
+		return fmt.Errorf("License installation failed: %w", r.newHTTPError("POST", response, http.StatusOK, http.StatusCreated))
 	}
 
 	return nil
@@ -228,23 +226,28 @@ func (r *Redfish) hpeSetLicense(mgr *ManagerData, l []byte) error {
 
 // AddLicense - add license to management board
 func (r *Redfish) AddLicense(mgr *ManagerData, l []byte) error {
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return errors.New("No authentication token found, is the session setup correctly?")
+	return r.AddLicenseContext(context.Background(), mgr, l)
+}
+
+// AddLicenseContext - same as AddLicense but uses the supplied context for all HTTP requests
+func (r *Redfish) AddLicenseContext(ctx context.Context, mgr *ManagerData, l []byte) error {
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
 	}
 
 	if r.Flavor == RedfishFlavorNotInitialized {
-		err := r.GetVendorFlavor()
+		err := r.GetVendorFlavorContext(ctx)
 		if err != nil {
 			return err
 		}
 	}
 
 	if r.Flavor == RedfishHP {
-		return r.hpSetLicense(mgr, l)
+		return r.hpSetLicense(ctx, mgr, l)
 	} else if r.Flavor == RedfishHPE {
-		return r.hpeSetLicense(mgr, l)
+		return r.hpeSetLicense(ctx, mgr, l)
 	}
 
-	return errors.New("License operations are not supported for this vendor. If this vendor supports license operations please file a feature request")
+	return r.newNotSupportedError("License operations are not supported for this vendor. If this vendor supports license operations please file a feature request")
 
 }
@@ -0,0 +1,84 @@
+package redfish
+
+import (
+	log "github.com/sirupsen/logrus"
+	"os"
+	"time"
+)
+
+// LogFields - structured data attached to a log message
+type LogFields map[string]interface{}
+
+// Logger - structured logger used by the client for verbose and debug output
+type Logger interface {
+	WithFields(LogFields) Logger
+	Debug(...interface{})
+	Info(...interface{})
+	Warning(...interface{})
+	Error(...interface{})
+}
+
+type logrusLogger struct {
+	entry log.FieldLogger
+}
+
+// NewLogrusLogger - Logger writing to the supplied logrus logger or entry
+func NewLogrusLogger(l log.FieldLogger) Logger {
+	return &logrusLogger{entry: l}
+}
+
+func (l *logrusLogger) WithFields(fields LogFields) Logger {
+	return &logrusLogger{entry: l.entry.WithFields(log.Fields(fields))}
+}
+
+func (l *logrusLogger) Debug(args ...interface{}) {
+	l.entry.Debug(args...)
+}
+
+func (l *logrusLogger) Info(args ...interface{}) {
+	l.entry.Info(args...)
+}
+
+func (l *logrusLogger) Warning(args ...interface{}) {
+	l.entry.Warning(args...)
+}
+
+func (l *logrusLogger) Error(args ...interface{}) {
+	l.entry.Error(args...)
+}
+
+// private logrus logger of this client, the global logrus configuration is never changed
+func (r *Redfish) newDefaultLogger() *log.Logger {
+	var logFmt = new(log.TextFormatter)
+	logFmt.FullTimestamp = true
+	logFmt.TimestampFormat = time.RFC3339
+
+	l := log.New()
+	l.SetOutput(os.Stderr)
+	l.SetFormatter(logFmt)
+
+	return l
+}
+
+// logger - Logger of this client, a private logrus logger is created on first use if none was supplied
+func (r *Redfish) logger() Logger {
+	if r.Logger != nil {
+		return r.Logger
+	}
+
+	r.loggerLock.Lock()
+	defer r.loggerLock.Unlock()
+
+	if r.defaultLogger == nil {
+		r.defaultLogger = r.newDefaultLogger()
+	}
+
+	// Debug can be enabled or disabled at any time
+	if r.Debug {
+		r.defaultLogger.SetLevel(log.DebugLevel)
+	} else {
+		r.defaultLogger.SetLevel(log.InfoLevel)
+	}
+
+	return &logrusLogger{entry: r.defaultLogger}
+}
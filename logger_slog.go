@@ -0,0 +1,56 @@
+//go:build go1.21
+
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger - Logger writing to the supplied log/slog logger
+func NewSlogLogger(l *slog.Logger) Logger {
+	return &slogLogger{logger: l}
+}
+
+func (l *slogLogger) WithFields(fields LogFields) Logger {
+	var keys = make([]string, 0, len(fields))
+	var args = make([]interface{}, 0, len(fields))
+
+	// map order is random, keep the order of attributes stable
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		args = append(args, slog.Any(key, fields[key]))
+	}
+
+	return &slogLogger{logger: l.logger.With(args...)}
+}
+
+func (l *slogLogger) log(level slog.Level, args ...interface{}) {
+	l.logger.Log(context.Background(), level, fmt.Sprint(args...))
+}
+
+func (l *slogLogger) Debug(args ...interface{}) {
+	l.log(slog.LevelDebug, args...)
+}
+
+func (l *slogLogger) Info(args ...interface{}) {
+	l.log(slog.LevelInfo, args...)
+}
+
+func (l *slogLogger) Warning(args ...interface{}) {
+	l.log(slog.LevelWarn, args...)
+}
+
+func (l *slogLogger) Error(args ...interface{}) {
+	l.log(slog.LevelError, args...)
+}
@@ -1,26 +1,83 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 	"strings"
+	"time"
 )
 
+// check credentials for HTTP basic authentication by accessing the Systems endpoint
+func (r *Redfish) loginBasicAuth(ctx context.Context) error {
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":           r.Hostname,
+			"port":               r.Port,
+			"timeout":            r.Timeout,
+			"flavor":             r.Flavor,
+			"flavor_string":      r.FlavorString,
+			"path":               r.Systems,
+			"method":             "GET",
+			"additional_headers": nil,
+			"use_basic_auth":     true,
+		}).Info("Checking credentials for HTTP basic authentication")
+	}
+	response, err := r.httpRequest(ctx, r.Systems, "GET", nil, nil, true)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("Login failed: %w", r.newHTTPError("GET", response, http.StatusOK))
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return r.newHTTPError("GET", response, http.StatusOK)
+	}
+
+	return nil
+}
+
+func (r *Redfish) makeLoginPayload() (string, error) {
+	raw, err := json.Marshal(loginPayload{
+		UserName: r.Username,
+		Password: r.Password,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
 // Login - Login to SessionEndpoint and get authentication token for this session
 func (r *Redfish) Login() error {
+	return r.LoginContext(context.Background())
+}
+
+// LoginContext - same as Login but uses the supplied context for all HTTP requests
+func (r *Redfish) LoginContext(ctx context.Context) error {
 	var sessions sessionServiceEndpoint
 
-	if r.Username == "" || r.Password == "" {
+	// Note: For certificate based authentication the service processor maps the client certificate to the account,
+	//       so neither Username nor Password are required
+	certLogin := len(r.ClientCertificates) > 0 && r.Username == "" && r.Password == ""
+
+	if !certLogin && (r.Username == "" || r.Password == "") {
 		return fmt.Errorf("Both Username and Password must be set")
 	}
 
+	// HTTP basic authentication doesn't use sessions, only check if the credentials are accepted
+	if r.UseBasicAuth {
+		return r.loginBasicAuth(ctx)
+	}
+
 	// Get session endpoint if not already defined by information from base endpoint .Links.Sessions
 	// because some implementations (e.g. INSPUR) report SessionService endpoint but don't implement it.
 	if r.Sessions == "" {
 		if r.Verbose {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":           r.Hostname,
 				"port":               r.Port,
 				"timeout":            r.Timeout,
@@ -29,16 +86,16 @@ func (r *Redfish) Login() error {
 				"path":               r.SessionService,
 				"method":             "GET",
 				"additional_headers": nil,
-				"use_basic_auth":     true,
+				"use_basic_auth":     !certLogin,
 			}).Info("Requesting path to session service")
 		}
-		response, err := r.httpRequest(r.SessionService, "GET", nil, nil, true)
+		response, err := r.httpRequest(ctx, r.SessionService, "GET", nil, nil, !certLogin)
 		if err != nil {
 			return err
 		}
 
 		if response.StatusCode != http.StatusOK {
-			return fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+			return r.newHTTPError("GET", response, http.StatusOK)
 		}
 
 		raw := response.Content
@@ -64,11 +121,23 @@ func (r *Redfish) Login() error {
 		}
 
 		r.Sessions = *sessions.Sessions.ID
+
+		if sessions.SessionTimeout > 0 {
+			r.setSessionTimeout(time.Duration(sessions.SessionTimeout) * time.Second)
+		}
 	}
 
-	jsonPayload := fmt.Sprintf("{ \"UserName\":\"%s\",\"Password\":\"%s\" }", r.Username, r.Password)
+	jsonPayload := "{}"
+	if !certLogin {
+		var err error
+
+		jsonPayload, err = r.makeLoginPayload()
+		if err != nil {
+			return err
+		}
+	}
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -77,11 +146,11 @@ func (r *Redfish) Login() error {
 			"path":               r.Sessions,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Sending login data to session service")
 	}
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -90,32 +159,23 @@ func (r *Redfish) Login() error {
 			"path":               r.Sessions,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
-			"payload":            jsonPayload,
+			"use_basic_auth":     r.UseBasicAuth,
+			"payload":            redactPayload(jsonPayload),
 		}).Debug("Sending login data to session service")
 	}
-	response, err := r.httpRequest(r.Sessions, "POST", nil, strings.NewReader(jsonPayload), false)
+	response, err := r.httpRequest(ctx, r.Sessions, "POST", nil, strings.NewReader(jsonPayload), false)
 	if err != nil {
 		return err
 	}
 
 	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
-		redfishError, err := r.ProcessError(response)
-		if err != nil {
-			return fmt.Errorf("HTTP POST for %s returned \"%s\" instead of \"200 OK\" or \"201 Created\"", response.URL, response.Status)
-		}
-		msg := r.GetErrorMessage(redfishError)
-		if msg != "" {
-			return fmt.Errorf("Login failed: %s", msg)
-		}
-		return fmt.Errorf("HTTP POST for %s returned \"%s\" instead of \"200 OK\" or \"201 Created\"", response.URL, response.Status)
+		return fmt.Errorf("Login failed: %w", r.newHTTPError("POST", response, http.StatusOK, http.StatusCreated))
 	}
 
 	token := response.Header.Get("x-auth-token")
 	if token == "" {
 		return fmt.Errorf("BUG: HTTP POST to SessionService endpoint %s returns OK but no X-Auth-Token in reply", response.URL)
 	}
-	r.AuthToken = &token
 
 	session := response.Header.Get("location")
 	if session == "" {
@@ -130,7 +190,7 @@ func (r *Redfish) Login() error {
 			session = fmt.Sprintf("https://%s%s", r.Hostname, session)
 		}
 	}
-	r.SessionLocation = &session
+	r.setSession(&token, &session)
 
 	return nil
 }
@@ -1,51 +1,74 @@
 package redfish
 
 import (
+	"context"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 )
 
 // Logout - Logout from SessionEndpoint and delete authentication token for this session
 func (r *Redfish) Logout() error {
+	return r.LogoutContext(context.Background())
+}
 
-	if r.AuthToken == nil {
-		// do nothing for Logout when we don't even have an authentication token
-		return nil
-	}
-	if *r.AuthToken == "" {
+// LogoutContext - same as Logout but uses the supplied context for all HTTP requests
+func (r *Redfish) LogoutContext(ctx context.Context) error {
+	r.StopSessionKeepAlive()
+
+	r.authLock.RLock()
+	token := r.AuthToken
+	location := r.SessionLocation
+	r.authLock.RUnlock()
+
+	if token == nil || *token == "" {
 		// do nothing for Logout when we don't even have an authentication token
 		return nil
 	}
 
-	if r.SessionLocation == nil || *r.SessionLocation == "" {
-		return fmt.Errorf("BUG: X-Auth-Token set (value: %s) but no SessionLocation for this session found", *r.AuthToken)
+	if location == nil || *location == "" {
+		return fmt.Errorf("BUG: X-Auth-Token set but no SessionLocation for this session found")
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
 			"flavor":             r.Flavor,
 			"flavor_string":      r.FlavorString,
-			"path":               *r.SessionLocation,
+			"path":               *location,
 			"method":             "DELETE",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Removing session authentication")
 	}
-	response, err := r.httpRequest(*r.SessionLocation, "DELETE", nil, nil, false)
+
+	// Note: The session must not be re-established if it has already expired, httpRequestRetry doesn't login again
+	response, err := r.httpRequestRetry(ctx, *location, "DELETE", nil, nil, false)
 	if err != nil {
 		return err
 	}
 
-	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP DELETE for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+	case http.StatusUnauthorized, http.StatusNotFound:
+		// session has already expired or has been removed
+		if r.Verbose {
+			r.logger().WithFields(LogFields{
+				"hostname":      r.Hostname,
+				"port":          r.Port,
+				"timeout":       r.Timeout,
+				"flavor":        r.Flavor,
+				"flavor_string": r.FlavorString,
+				"path":          *location,
+				"status":        response.Status,
+			}).Info("Session is already gone")
+		}
+	default:
+		return r.newHTTPError("DELETE", response, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
 	}
 
-	r.AuthToken = nil
-	r.SessionLocation = nil
+	r.setSession(nil, nil)
 
 	return nil
 }
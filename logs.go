@@ -0,0 +1,490 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// default interval to poll a log for new entries
+const defaultLogFollowInterval = 10 * time.Second
+
+// severities of log entries defined by the standard, used to map the severities of a filter to the values
+// reported by the service processor because $filter compares case sensitive
+var logEntrySeverityValues = []string{"OK", "Warning", "Critical"}
+
+// LogEntryFilter - select log entries, unset fields match all entries. The filter is passed to the service
+// processor as $filter, $top and $skip if supported, otherwise the entries are selected by Match.
+type LogEntryFilter struct {
+	// Severity - severities to include (e.g. Warning or Critical), compared case insensitive
+	Severity []string
+	// Since - include only entries created at or after Since
+	Since time.Time
+	// Until - include only entries created before Until
+	Until time.Time
+	// Skip - number of selected entries to skip, ignored by FollowLogEntries
+	Skip int
+	// Limit - maximal number of entries to return, 0 for no limit, ignored by FollowLogEntries
+	Limit int
+}
+
+// creation time of the log entry, false if the entry has no valid creation time
+func logEntryCreated(entry *LogEntryData) (time.Time, bool) {
+	if entry.Created == nil {
+		return time.Time{}, false
+	}
+
+	created, err := time.Parse(time.RFC3339, *entry.Created)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return created, true
+}
+
+// $filter expression for the filter and whether it selects exactly the entries matched by Match. Severities not
+// defined by the standard can't be compared case insensitive by the service processor and are left to Match.
+func (f LogEntryFilter) expression() (string, bool) {
+	var parts = make([]string, 0)
+	var exact = true
+
+	if len(f.Severity) > 0 {
+		var severities = make([]string, 0, len(f.Severity))
+
+		for _, s := range f.Severity {
+			severity, err := allowableValue("Severity", s, logEntrySeverityValues)
+			if err != nil {
+				exact = false
+				severities = nil
+				break
+			}
+			severities = append(severities, "Severity eq '"+severity+"'")
+		}
+
+		if len(severities) > 0 {
+			parts = append(parts, "("+strings.Join(severities, " or ")+")")
+		}
+	}
+
+	if !f.Since.IsZero() {
+		parts = append(parts, "Created ge '"+f.Since.UTC().Format(time.RFC3339)+"'")
+	}
+	if !f.Until.IsZero() {
+		parts = append(parts, "Created lt '"+f.Until.UTC().Format(time.RFC3339)+"'")
+	}
+
+	return strings.Join(parts, " and "), exact
+}
+
+// query parameters to select the entries of the filter on the service processor, if supported. Returns true
+// if Skip and Limit are applied by the service processor.
+func (r *Redfish) logEntryQuery(filter LogEntryFilter) (QueryOptions, bool) {
+	var q QueryOptions
+
+	pf := r.ProtocolFeatures
+	if pf == nil {
+		pf = &ProtocolFeaturesSupported{}
+	}
+
+	// $skip and $top are applied after $filter, so they can only be passed if the service processor selects
+	// exactly the entries matched by the filter
+	expr, exact := filter.expression()
+	if expr != "" {
+		if !pf.FilterQuery {
+			return q, false
+		}
+		q.Filter = expr
+	}
+
+	if !exact || !pf.TopSkipQuery {
+		return q, false
+	}
+
+	q.Skip = filter.Skip
+	q.Top = filter.Limit
+	return q, true
+}
+
+// Match - check if the log entry is selected by the filter (Skip and Limit are not considered). If a time range
+// is set, entries without a valid creation time don't match.
+func (f LogEntryFilter) Match(entry *LogEntryData) bool {
+	if len(f.Severity) > 0 {
+		if entry.Severity == nil {
+			return false
+		}
+
+		found := false
+		for _, s := range f.Severity {
+			if strings.EqualFold(s, *entry.Severity) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if !f.Since.IsZero() || !f.Until.IsZero() {
+		created, ok := logEntryCreated(entry)
+		if !ok {
+			return false
+		}
+
+		if !f.Since.IsZero() && created.Before(f.Since) {
+			return false
+		}
+		if !f.Until.IsZero() && !created.Before(f.Until) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *Redfish) getLogServices(ctx context.Context, endpoint string) ([]*LogServiceData, error) {
+	var result = make([]*LogServiceData, 0)
+
+	members, err := r.getCollectionContent(ctx, endpoint)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d LogServiceData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// GetSystemLogServices - get log services (e.g. the system event log) of a system
+func (r *Redfish) GetSystemLogServices(sd *SystemData) ([]*LogServiceData, error) {
+	return r.GetSystemLogServicesContext(context.Background(), sd)
+}
+
+// GetSystemLogServicesContext - same as GetSystemLogServices but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemLogServicesContext(ctx context.Context, sd *SystemData) ([]*LogServiceData, error) {
+	if !r.isAuthenticated() {
+		return make([]*LogServiceData, 0), ErrNotAuthenticated
+	}
+
+	if sd.LogServices == nil || sd.LogServices.ID == nil || *sd.LogServices.ID == "" {
+		return make([]*LogServiceData, 0), r.newNotSupportedError("System does not provide log services")
+	}
+
+	return r.getLogServices(ctx, *sd.LogServices.ID)
+}
+
+// GetManagerLogServices - get log services of a manager
+func (r *Redfish) GetManagerLogServices(md *ManagerData) ([]*LogServiceData, error) {
+	return r.GetManagerLogServicesContext(context.Background(), md)
+}
+
+// GetManagerLogServicesContext - same as GetManagerLogServices but uses the supplied context for all HTTP requests
+func (r *Redfish) GetManagerLogServicesContext(ctx context.Context, md *ManagerData) ([]*LogServiceData, error) {
+	if !r.isAuthenticated() {
+		return make([]*LogServiceData, 0), ErrNotAuthenticated
+	}
+
+	if md.LogServices == nil || md.LogServices.ID == nil || *md.LogServices.ID == "" {
+		return make([]*LogServiceData, 0), r.newNotSupportedError("Manager does not provide log services")
+	}
+
+	return r.getLogServices(ctx, *md.LogServices.ID)
+}
+
+// call fn for every entry of the log selected by the query parameters, page by page, until fn returns false. Log entry
+// collections usually contain the complete entries, only members reported as reference are fetched.
+func (r *Redfish) forEachLogEntry(ctx context.Context, ls *LogServiceData, q QueryOptions, fn func(*LogEntryData) bool) error {
+	var visited = make(map[string]bool)
+
+	if ls.Entries == nil || ls.Entries.ID == nil || *ls.Entries.ID == "" {
+		return r.newNotSupportedError("Log service does not provide log entries")
+	}
+
+	if r.supportsExpand() {
+		q.Expand = r.expandMembersQuery()
+	}
+	next := q.Endpoint(*ls.Entries.ID)
+
+	for next != "" {
+		var page struct {
+			Members  []json.RawMessage `json:"Members"`
+			NextLink *string           `json:"Members@odata.nextLink"`
+		}
+
+		if visited[next] {
+			return fmt.Errorf("BUG: Members@odata.nextLink of collection points to already fetched page %s", next)
+		}
+		visited[next] = true
+
+		_, err := r.getJSON(ctx, next, &page)
+		if err != nil {
+			return err
+		}
+
+		entries := make([]*LogEntryData, len(page.Members))
+		references := make([]int, 0)
+		for i, raw := range page.Members {
+			var m map[string]json.RawMessage
+			var entry LogEntryData
+
+			memberEndpoint, err := decodeExpandedMember(raw, &m)
+			if err != nil {
+				return err
+			}
+
+			if len(m) <= 1 {
+				references = append(references, i)
+			} else {
+				err = json.Unmarshal(raw, &entry)
+				if err != nil {
+					return err
+				}
+			}
+
+			entry.SelfEndpoint = &memberEndpoint
+			entries[i] = &entry
+		}
+
+		err = r.fetchMembers(ctx, len(references), func(ctx context.Context, i int) error {
+			var entry LogEntryData
+
+			e := entries[references[i]]
+			_, err := r.getJSON(ctx, *e.SelfEndpoint, &entry)
+			if err != nil {
+				return err
+			}
+
+			entry.SelfEndpoint = e.SelfEndpoint
+			entries[references[i]] = &entry
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if !fn(entry) {
+				return nil
+			}
+		}
+
+		next = ""
+		if page.NextLink != nil {
+			next = *page.NextLink
+		}
+	}
+
+	return nil
+}
+
+// GetLogEntries - get entries of a log service selected by the filter
+func (r *Redfish) GetLogEntries(ls *LogServiceData, filter LogEntryFilter) ([]*LogEntryData, error) {
+	return r.GetLogEntriesContext(context.Background(), ls, filter)
+}
+
+// GetLogEntriesContext - same as GetLogEntries but uses the supplied context for all HTTP requests
+func (r *Redfish) GetLogEntriesContext(ctx context.Context, ls *LogServiceData, filter LogEntryFilter) ([]*LogEntryData, error) {
+	var result = make([]*LogEntryData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	q, paged := r.logEntryQuery(filter)
+
+	skip := filter.Skip
+	if paged {
+		skip = 0
+	}
+
+	err := r.forEachLogEntry(ctx, ls, q, func(entry *LogEntryData) bool {
+		if !filter.Match(entry) {
+			return true
+		}
+
+		if skip > 0 {
+			skip--
+			return true
+		}
+
+		result = append(result, entry)
+		return filter.Limit <= 0 || len(result) < filter.Limit
+	})
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// key to recognize log entries that have already been reported, the creation time is included
+// because some service processors reuse the Id of entries after the log has been cleared
+func logEntryKey(entry *LogEntryData) string {
+	var created string
+
+	if entry.Created != nil {
+		created = *entry.Created
+	}
+	return *entry.SelfEndpoint + "|" + created
+}
+
+// logFollowState - log entries already known to FollowLogEntries. Entries created before the newest entry are
+// recognized by their creation time, so only the entries with the newest creation time and entries without a
+// valid creation time have to be remembered.
+type logFollowState struct {
+	newest time.Time
+	latest map[string]bool
+	// entries without valid creation time
+	untimed map[string]bool
+}
+
+func newLogFollowState() *logFollowState {
+	return &logFollowState{
+		latest:  make(map[string]bool),
+		untimed: make(map[string]bool),
+	}
+}
+
+func (s *logFollowState) add(entry *LogEntryData) {
+	key := logEntryKey(entry)
+
+	created, ok := logEntryCreated(entry)
+	if !ok {
+		s.untimed[key] = true
+		return
+	}
+
+	if created.After(s.newest) {
+		s.newest = created
+		s.latest = make(map[string]bool)
+	}
+	if created.Equal(s.newest) {
+		s.latest[key] = true
+	}
+}
+
+func (s *logFollowState) known(entry *LogEntryData) bool {
+	key := logEntryKey(entry)
+
+	created, ok := logEntryCreated(entry)
+	if !ok {
+		return s.untimed[key]
+	}
+
+	if created.Before(s.newest) {
+		return true
+	}
+	return created.Equal(s.newest) && s.latest[key]
+}
+
+// FollowLogEntries - poll the log service every interval (default 10 seconds) and call fn for every new entry
+// selected by the filter. Entries present when following starts are not reported. Following stops if fn
+// returns an error, which is returned by FollowLogEntries.
+//
+// Note: New entries are recognized by their creation time, entries created before the newest entry already
+// reported (e.g. after the clock of the service processor has been set back) are not reported.
+func (r *Redfish) FollowLogEntries(ls *LogServiceData, filter LogEntryFilter, interval time.Duration, fn func(*LogEntryData) error) error {
+	return r.FollowLogEntriesContext(context.Background(), ls, filter, interval, fn)
+}
+
+// FollowLogEntriesContext - same as FollowLogEntries but uses the supplied context for all HTTP requests,
+// following stops when the context is done
+func (r *Redfish) FollowLogEntriesContext(ctx context.Context, ls *LogServiceData, filter LogEntryFilter, interval time.Duration, fn func(*LogEntryData) error) error {
+	var state = newLogFollowState()
+
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	if interval <= 0 {
+		interval = defaultLogFollowInterval
+	}
+
+	filter.Skip = 0
+	filter.Limit = 0
+	q, _ := r.logEntryQuery(filter)
+
+	err := r.forEachLogEntry(ctx, ls, q, func(entry *LogEntryData) bool {
+		state.add(entry)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		// the state is rebuilt on every poll, so it only contains entries still present in the log
+		var current = newLogFollowState()
+		var fnErr error
+
+		err = r.forEachLogEntry(ctx, ls, q, func(entry *LogEntryData) bool {
+			current.add(entry)
+
+			if state.known(entry) || !filter.Match(entry) {
+				return true
+			}
+
+			fnErr = fn(entry)
+			return fnErr == nil
+		})
+		if fnErr != nil {
+			return fnErr
+		}
+		if err != nil {
+			return err
+		}
+
+		state = current
+	}
+}
+
+// ClearLog - delete all entries of a log service
+func (r *Redfish) ClearLog(ls *LogServiceData) error {
+	return r.ClearLogContext(context.Background(), ls)
+}
+
+// ClearLogContext - same as ClearLog but uses the supplied context for all HTTP requests
+func (r *Redfish) ClearLogContext(ctx context.Context, ls *LogServiceData) error {
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	if ls.Actions == nil || ls.Actions.ClearLog == nil || ls.Actions.ClearLog.Target == "" {
+		return r.newNotSupportedError("Log service does not support clearing the log")
+	}
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":      r.Hostname,
+			"port":          r.Port,
+			"timeout":       r.Timeout,
+			"flavor":        r.Flavor,
+			"flavor_string": r.FlavorString,
+			"path":          ls.Actions.ClearLog.Target,
+		}).Info("Clearing log")
+	}
+
+	// some service processors reject actions without a body
+	_, err := r.sendJSON(ctx, "POST", ls.Actions.ClearLog.Target, nil, struct{}{})
+	return err
+}
@@ -1,70 +1,51 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 )
 
 // GetManagers - get array of managers and their endpoints
 func (r *Redfish) GetManagers() ([]string, error) {
-	var mgrs OData
-	var result = make([]string, 0)
-
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return result, errors.New("No authentication token found, is the session setup correctly?")
-	}
+	return r.GetManagersContext(context.Background())
+}
 
-	if r.Verbose {
-		log.WithFields(log.Fields{
-			"hostname":           r.Hostname,
-			"port":               r.Port,
-			"timeout":            r.Timeout,
-			"flavor":             r.Flavor,
-			"flavor_string":      r.FlavorString,
-			"path":               r.Managers,
-			"method":             "GET",
-			"additional_headers": nil,
-			"use_basic_auth":     false,
-		}).Info("Requesting user accounts")
-	}
-	response, err := r.httpRequest(r.Managers, "GET", nil, nil, false)
-	if err != nil {
-		return result, err
-	}
+// GetManagersContext - same as GetManagers but uses the supplied context for all HTTP requests
+func (r *Redfish) GetManagersContext(ctx context.Context) ([]string, error) {
+	var result = make([]string, 0)
 
-	raw := response.Content
-	if response.StatusCode != http.StatusOK {
-		return result, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
 	}
 
-	err = json.Unmarshal(raw, &mgrs)
+	result, err := r.GetCollectionMembersContext(ctx, r.Managers)
 	if err != nil {
 		return result, err
 	}
 
-	if len(mgrs.Members) == 0 {
+	if len(result) == 0 {
 		return result, fmt.Errorf("BUG: Missing or empty Members attribute in Managers")
 	}
-
-	for _, m := range mgrs.Members {
-		result = append(result, *m.ID)
-	}
 	return result, nil
 }
 
 // GetManagerData - get manager data for an particular account
 func (r *Redfish) GetManagerData(managerEndpoint string) (*ManagerData, error) {
+	return r.GetManagerDataContext(context.Background(), managerEndpoint)
+}
+
+// GetManagerDataContext - same as GetManagerData but uses the supplied context for all HTTP requests
+func (r *Redfish) GetManagerDataContext(ctx context.Context, managerEndpoint string) (*ManagerData, error) {
 	var result ManagerData
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return nil, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -73,10 +54,10 @@ func (r *Redfish) GetManagerData(managerEndpoint string) (*ManagerData, error) {
 			"path":               managerEndpoint,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting information for user")
 	}
-	response, err := r.httpRequest(managerEndpoint, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, managerEndpoint, "GET", nil, nil, false)
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +65,7 @@ func (r *Redfish) GetManagerData(managerEndpoint string) (*ManagerData, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return nil, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &result)
@@ -92,27 +73,57 @@ func (r *Redfish) GetManagerData(managerEndpoint string) (*ManagerData, error) {
 		return nil, err
 	}
 	result.SelfEndpoint = &managerEndpoint
+	result.ETag = etagFromResult(response, result.ETag)
 	return &result, nil
 }
 
-// MapManagersByID - map ID -> manager data
-func (r *Redfish) MapManagersByID() (map[string]*ManagerData, error) {
-	var result = make(map[string]*ManagerData)
+// get data of all managers, by a single request if the service processor supports $expand
+func (r *Redfish) getAllManagerData(ctx context.Context) ([]*ManagerData, error) {
+	var result = make([]*ManagerData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
 
-	ml, err := r.GetManagers()
+	members, err := r.getCollectionContent(ctx, r.Managers)
 	if err != nil {
 		return result, err
 	}
 
-	for _, mgr := range ml {
-		m, err := r.GetManagerData(mgr)
+	for i := range members {
+		var d ManagerData
+
+		err = json.Unmarshal(members[i].content, &d)
 		if err != nil {
 			return result, err
 		}
 
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// MapManagersByID - map ID -> manager data
+func (r *Redfish) MapManagersByID() (map[string]*ManagerData, error) {
+	return r.MapManagersByIDContext(context.Background())
+}
+
+// MapManagersByIDContext - same as MapManagersByID but uses the supplied context for all HTTP requests
+func (r *Redfish) MapManagersByIDContext(ctx context.Context) (map[string]*ManagerData, error) {
+	var result = make(map[string]*ManagerData)
+
+	dl, err := r.getAllManagerData(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, m := range dl {
 		// should NEVER happen
 		if m.ID == nil {
-			return result, fmt.Errorf("BUG: No Id found or Id is null in JSON data from %s", mgr)
+			return result, fmt.Errorf("BUG: No Id found or Id is null in JSON data from %s", *m.SelfEndpoint)
 		}
 		result[*m.ID] = m
 	}
@@ -122,22 +133,22 @@ func (r *Redfish) MapManagersByID() (map[string]*ManagerData, error) {
 
 // MapManagersByUUID - map UUID -> manager data
 func (r *Redfish) MapManagersByUUID() (map[string]*ManagerData, error) {
+	return r.MapManagersByUUIDContext(context.Background())
+}
+
+// MapManagersByUUIDContext - same as MapManagersByUUID but uses the supplied context for all HTTP requests
+func (r *Redfish) MapManagersByUUIDContext(ctx context.Context) (map[string]*ManagerData, error) {
 	var result = make(map[string]*ManagerData)
 
-	ml, err := r.GetManagers()
+	dl, err := r.getAllManagerData(ctx)
 	if err != nil {
 		return result, err
 	}
 
-	for _, mgr := range ml {
-		m, err := r.GetManagerData(mgr)
-		if err != nil {
-			return result, err
-		}
-
+	for _, m := range dl {
 		// should NEVER happen
 		if m.UUID == nil {
-			return result, fmt.Errorf("BUG: No UUID found or UUID is null in JSON data from %s", mgr)
+			return result, fmt.Errorf("BUG: No UUID found or UUID is null in JSON data from %s", *m.SelfEndpoint)
 		}
 		result[*m.UUID] = m
 	}
@@ -0,0 +1,98 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetSystemMemory - get memory modules of a system
+func (r *Redfish) GetSystemMemory(sd *SystemData) ([]*MemoryData, error) {
+	return r.GetSystemMemoryContext(context.Background(), sd)
+}
+
+// GetSystemMemoryContext - same as GetSystemMemory but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemMemoryContext(ctx context.Context, sd *SystemData) ([]*MemoryData, error) {
+	var result = make([]*MemoryData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	if sd.Memory == nil || sd.Memory.ID == nil || *sd.Memory.ID == "" {
+		return result, r.newNotSupportedError("System does not provide a memory collection")
+	}
+
+	members, err := r.getCollectionContent(ctx, *sd.Memory.ID)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d MemoryData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// MapMemoryByID - map memory modules of a system by ID
+func (r *Redfish) MapMemoryByID(sd *SystemData) (map[string]*MemoryData, error) {
+	return r.MapMemoryByIDContext(context.Background(), sd)
+}
+
+// MapMemoryByIDContext - same as MapMemoryByID but uses the supplied context for all HTTP requests
+func (r *Redfish) MapMemoryByIDContext(ctx context.Context, sd *SystemData) (map[string]*MemoryData, error) {
+	var result = make(map[string]*MemoryData)
+
+	ml, err := r.GetSystemMemoryContext(ctx, sd)
+	if err != nil {
+		return result, err
+	}
+
+	for _, m := range ml {
+		// should NEVER happen
+		if m.ID == nil {
+			return result, fmt.Errorf("BUG: No Id found for Memory at %s", *m.SelfEndpoint)
+		}
+
+		result[*m.ID] = m
+	}
+
+	return result, nil
+}
+
+// MapMemoryByDeviceLocator - map memory modules of a system by the device locator (label of the slot),
+// memory modules without a device locator are skipped
+func (r *Redfish) MapMemoryByDeviceLocator(sd *SystemData) (map[string]*MemoryData, error) {
+	return r.MapMemoryByDeviceLocatorContext(context.Background(), sd)
+}
+
+// MapMemoryByDeviceLocatorContext - same as MapMemoryByDeviceLocator but uses the supplied context for all HTTP requests
+func (r *Redfish) MapMemoryByDeviceLocatorContext(ctx context.Context, sd *SystemData) (map[string]*MemoryData, error) {
+	var result = make(map[string]*MemoryData)
+
+	ml, err := r.GetSystemMemoryContext(ctx, sd)
+	if err != nil {
+		return result, err
+	}
+
+	for _, m := range ml {
+		// DeviceLocator is optional
+		if m.DeviceLocator == nil || *m.DeviceLocator == "" {
+			continue
+		}
+
+		result[*m.DeviceLocator] = m
+	}
+
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// GetChassisNetworkAdapters - get network adapters of a chassis
+func (r *Redfish) GetChassisNetworkAdapters(cd *ChassisData) ([]*NetworkAdapterData, error) {
+	return r.GetChassisNetworkAdaptersContext(context.Background(), cd)
+}
+
+// GetChassisNetworkAdaptersContext - same as GetChassisNetworkAdapters but uses the supplied context for all HTTP requests
+func (r *Redfish) GetChassisNetworkAdaptersContext(ctx context.Context, cd *ChassisData) ([]*NetworkAdapterData, error) {
+	var result = make([]*NetworkAdapterData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	if cd.NetworkAdapters == nil || cd.NetworkAdapters.ID == nil || *cd.NetworkAdapters.ID == "" {
+		return result, r.newNotSupportedError("Chassis does not provide a network adapter collection")
+	}
+
+	members, err := r.getCollectionContent(ctx, *cd.NetworkAdapters.ID)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d NetworkAdapterData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// GetNetworkAdapterPorts - get ports of a network adapter
+func (r *Redfish) GetNetworkAdapterPorts(na *NetworkAdapterData) ([]*NetworkPortData, error) {
+	return r.GetNetworkAdapterPortsContext(context.Background(), na)
+}
+
+// GetNetworkAdapterPortsContext - same as GetNetworkAdapterPorts but uses the supplied context for all HTTP requests
+func (r *Redfish) GetNetworkAdapterPortsContext(ctx context.Context, na *NetworkAdapterData) ([]*NetworkPortData, error) {
+	var result = make([]*NetworkPortData, 0)
+	var endpoint string
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	// Ports replaces the deprecated NetworkPorts collection in newer schema versions
+	if na.Ports != nil && na.Ports.ID != nil && *na.Ports.ID != "" {
+		endpoint = *na.Ports.ID
+	} else if na.NetworkPorts != nil && na.NetworkPorts.ID != nil && *na.NetworkPorts.ID != "" {
+		endpoint = *na.NetworkPorts.ID
+	} else {
+		return result, r.newNotSupportedError("Network adapter does not provide a port collection")
+	}
+
+	members, err := r.getCollectionContent(ctx, endpoint)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d NetworkPortData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
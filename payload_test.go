@@ -0,0 +1,215 @@
+package redfish
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// values with characters that must be escaped in JSON
+var specialValues = []struct {
+	name  string
+	value string
+}{
+	{"plain", "admin"},
+	{"quotes", `pa"ss"word`},
+	{"backslashes", `C:\path\to\"key\"`},
+	{"newlines", "line1\nline2\r\nline3"},
+	{"control", "tab\there\x00\x1f"},
+	{"unicode", "päßwörd-日本語-🔑"},
+	{"json injection", `x", "RoleId": "Administrator`},
+	{"html", "<script>&amp;</script>"},
+}
+
+// decode payload and check it is valid JSON with exactly the expected keys
+func decodePayload(t *testing.T, payload string, keys ...string) map[string]interface{} {
+	var result map[string]interface{}
+
+	err := json.Unmarshal([]byte(payload), &result)
+	if err != nil {
+		t.Fatalf("payload %q is not valid JSON: %s", payload, err)
+	}
+
+	var got = make([]string, 0, len(result))
+	for k := range result {
+		got = append(got, k)
+	}
+	sort.Strings(got)
+	sort.Strings(keys)
+
+	if !reflect.DeepEqual(got, keys) {
+		t.Fatalf("payload %q has keys %v, expected %v", payload, got, keys)
+	}
+	return result
+}
+
+func TestMakeLoginPayload(t *testing.T) {
+	for _, tc := range specialValues {
+		t.Run(tc.name, func(t *testing.T) {
+			var r = Redfish{Username: tc.value, Password: tc.value}
+
+			payload, err := r.makeLoginPayload()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := decodePayload(t, payload, "UserName", "Password")
+			if m["UserName"] != tc.value || m["Password"] != tc.value {
+				t.Errorf("payload %q doesn't contain the unmodified values", payload)
+			}
+		})
+	}
+}
+
+func TestMakeAccountPayloadVanilla(t *testing.T) {
+	var r Redfish
+
+	for _, tc := range specialValues {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := r.makeAccountPayloadVanilla(AccountCreateData{UserName: tc.value, Password: tc.value, Role: "Operator"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := decodePayload(t, payload, "UserName", "Password", "RoleId")
+			if m["UserName"] != tc.value || m["Password"] != tc.value || m["RoleId"] != "Operator" {
+				t.Errorf("payload %q doesn't contain the unmodified values", payload)
+			}
+		})
+	}
+}
+
+func TestHpMakeAccountPayload(t *testing.T) {
+	var r Redfish
+
+	for _, tc := range specialValues {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := r.hpMakeAccountPayload(AccountCreateData{
+				UserName:          tc.value,
+				Password:          tc.value,
+				OemHpPrivilegeMap: &AccountPrivilegeMapOemHp{Login: true},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := decodePayload(t, payload, "UserName", "Password", "Oem")
+			if m["UserName"] != tc.value || m["Password"] != tc.value {
+				t.Errorf("payload %q doesn't contain the unmodified values", payload)
+			}
+
+			hp := m["Oem"].(map[string]interface{})["Hp"].(map[string]interface{})
+			if hp["LoginName"] != tc.value {
+				t.Errorf("payload %q doesn't contain the unmodified login name", payload)
+			}
+			if hp["Privileges"].(map[string]interface{})["LoginPriv"] != true {
+				t.Errorf("payload %q doesn't contain the privileges", payload)
+			}
+		})
+	}
+}
+
+func TestMakeCertificateImportPayload(t *testing.T) {
+	var r Redfish
+
+	var certs = []struct {
+		name  string
+		value string
+	}{
+		{"pem", "-----BEGIN CERTIFICATE-----\nMIIB\"x\\y\n-----END CERTIFICATE-----\n"},
+		{"crlf", "-----BEGIN CERTIFICATE-----\r\nMIIB\r\n-----END CERTIFICATE-----\r\n"},
+		{"unicode", "-----BEGIN CERTIFICATE-----\nÄÖÜ\n-----END CERTIFICATE-----"},
+	}
+
+	for _, tc := range certs {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := r.makeCertificateImportPayload(tc.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := decodePayload(t, payload, "Certificate")
+			if m["Certificate"] != tc.value {
+				t.Errorf("payload %q doesn't contain the unmodified certificate", payload)
+			}
+		})
+	}
+}
+
+func TestMakeCSRPayloadVanilla(t *testing.T) {
+	var r = Redfish{Hostname: "bmc.example.com"}
+
+	for _, tc := range specialValues {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := r.makeCSRPayloadVanilla(CSRData{S: tc.value, L: tc.value, O: tc.value, OU: tc.value, CN: tc.value})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := decodePayload(t, payload, "Country", "State", "City", "OrgName", "OrgUnit", "CommonName")
+			if m["Country"] != "XX" {
+				t.Errorf("empty country not replaced by XX in payload %q", payload)
+			}
+			for _, k := range []string{"State", "City", "OrgName", "OrgUnit", "CommonName"} {
+				if m[k] != tc.value {
+					t.Errorf("payload %q doesn't contain the unmodified value of %s", payload, k)
+				}
+			}
+		})
+	}
+
+	t.Run("optional fields", func(t *testing.T) {
+		payload, err := r.makeCSRPayloadVanilla(CSRData{C: "DE"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m := decodePayload(t, payload, "Country", "CommonName")
+		if m["Country"] != "DE" || m["CommonName"] != "bmc.example.com" {
+			t.Errorf("unexpected payload %q", payload)
+		}
+	})
+}
+
+func TestMakeCSRPayloadHP(t *testing.T) {
+	var r = Redfish{Hostname: "bmc.example.com"}
+
+	for _, tc := range specialValues {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := r.makeCSRPayloadHP(CSRData{O: tc.value, CN: tc.value})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := decodePayload(t, payload, "Country", "State", "City", "OrgName", "OrgUnit", "CommonName")
+			if m["Country"] != "XX" {
+				t.Errorf("empty country not replaced by XX in payload %q", payload)
+			}
+			if m["State"] != "-" || m["City"] != "-" || m["OrgUnit"] != "-" {
+				t.Errorf("empty fields not replaced by - in payload %q", payload)
+			}
+			if m["OrgName"] != tc.value || m["CommonName"] != tc.value {
+				t.Errorf("payload %q doesn't contain the unmodified values", payload)
+			}
+		})
+	}
+}
+
+func TestHpHpePrepareLicensePayload(t *testing.T) {
+	var r Redfish
+
+	for _, tc := range specialValues {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := r.hpHpePrepareLicensePayload([]byte(tc.value))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			m := decodePayload(t, payload, "LicenseKey")
+			if m["LicenseKey"] != tc.value {
+				t.Errorf("payload %q doesn't contain the unmodified license key", payload)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+)
+
+func decodePCIeFunctions(members []collectionMember) ([]*PCIeFunctionData, error) {
+	var result = make([]*PCIeFunctionData, 0)
+
+	for i := range members {
+		var d PCIeFunctionData
+
+		err := json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+func decodePCIeDevices(members []collectionMember) ([]*PCIeDeviceData, error) {
+	var result = make([]*PCIeDeviceData, 0)
+
+	for i := range members {
+		var d PCIeDeviceData
+
+		err := json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// GetSystemPCIeDevices - get PCIe devices of a system
+func (r *Redfish) GetSystemPCIeDevices(sd *SystemData) ([]*PCIeDeviceData, error) {
+	return r.GetSystemPCIeDevicesContext(context.Background(), sd)
+}
+
+// GetSystemPCIeDevicesContext - same as GetSystemPCIeDevices but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemPCIeDevicesContext(ctx context.Context, sd *SystemData) ([]*PCIeDeviceData, error) {
+	if !r.isAuthenticated() {
+		return make([]*PCIeDeviceData, 0), ErrNotAuthenticated
+	}
+
+	if len(sd.PCIeDevices) == 0 {
+		return make([]*PCIeDeviceData, 0), r.newNotSupportedError("System does not provide PCIe devices")
+	}
+
+	// PCIeDevices of a system is a list of links, not a collection
+	members, err := r.getLinkedContent(ctx, sd.PCIeDevices)
+	if err != nil {
+		return make([]*PCIeDeviceData, 0), err
+	}
+
+	return decodePCIeDevices(members)
+}
+
+// GetSystemPCIeFunctions - get PCIe functions of a system
+func (r *Redfish) GetSystemPCIeFunctions(sd *SystemData) ([]*PCIeFunctionData, error) {
+	return r.GetSystemPCIeFunctionsContext(context.Background(), sd)
+}
+
+// GetSystemPCIeFunctionsContext - same as GetSystemPCIeFunctions but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemPCIeFunctionsContext(ctx context.Context, sd *SystemData) ([]*PCIeFunctionData, error) {
+	if !r.isAuthenticated() {
+		return make([]*PCIeFunctionData, 0), ErrNotAuthenticated
+	}
+
+	if len(sd.PCIeFunctions) == 0 {
+		return make([]*PCIeFunctionData, 0), r.newNotSupportedError("System does not provide PCIe functions")
+	}
+
+	// PCIeFunctions of a system is a list of links, not a collection
+	members, err := r.getLinkedContent(ctx, sd.PCIeFunctions)
+	if err != nil {
+		return make([]*PCIeFunctionData, 0), err
+	}
+
+	return decodePCIeFunctions(members)
+}
+
+// GetChassisPCIeDevices - get PCIe devices of a chassis
+func (r *Redfish) GetChassisPCIeDevices(cd *ChassisData) ([]*PCIeDeviceData, error) {
+	return r.GetChassisPCIeDevicesContext(context.Background(), cd)
+}
+
+// GetChassisPCIeDevicesContext - same as GetChassisPCIeDevices but uses the supplied context for all HTTP requests
+func (r *Redfish) GetChassisPCIeDevicesContext(ctx context.Context, cd *ChassisData) ([]*PCIeDeviceData, error) {
+	if !r.isAuthenticated() {
+		return make([]*PCIeDeviceData, 0), ErrNotAuthenticated
+	}
+
+	if cd.PCIeDevices == nil || cd.PCIeDevices.ID == nil || *cd.PCIeDevices.ID == "" {
+		return make([]*PCIeDeviceData, 0), r.newNotSupportedError("Chassis does not provide a PCIe device collection")
+	}
+
+	members, err := r.getCollectionContent(ctx, *cd.PCIeDevices.ID)
+	if err != nil {
+		return make([]*PCIeDeviceData, 0), err
+	}
+
+	return decodePCIeDevices(members)
+}
+
+// GetPCIeFunctions - get functions of a PCIe device
+func (r *Redfish) GetPCIeFunctions(dev *PCIeDeviceData) ([]*PCIeFunctionData, error) {
+	return r.GetPCIeFunctionsContext(context.Background(), dev)
+}
+
+// GetPCIeFunctionsContext - same as GetPCIeFunctions but uses the supplied context for all HTTP requests
+func (r *Redfish) GetPCIeFunctionsContext(ctx context.Context, dev *PCIeDeviceData) ([]*PCIeFunctionData, error) {
+	var result = make([]*PCIeFunctionData, 0)
+	var members []collectionMember
+	var err error
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	// newer schema versions provide a collection, older ones a list of links
+	if dev.PCIeFunctions != nil && dev.PCIeFunctions.ID != nil && *dev.PCIeFunctions.ID != "" {
+		members, err = r.getCollectionContent(ctx, *dev.PCIeFunctions.ID)
+	} else if dev.Links != nil {
+		members, err = r.getLinkedContent(ctx, dev.Links.PCIeFunctions)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	return decodePCIeFunctions(members)
+}
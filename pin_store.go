@@ -0,0 +1,101 @@
+package redfish
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// PinStore - storage for pinned server certificates, mapping "host:port" to the SHA256 fingerprint of it's certificate
+type PinStore interface {
+	// GetPin - get fingerprint for "host:port", an empty string is returned if no certificate has been pinned yet
+	GetPin(string) (string, error)
+	// SetPin - pin fingerprint for "host:port"
+	SetPin(string, string) error
+}
+
+// FilePinStore - persistent PinStore, fingerprints are stored as JSON object in a file
+type FilePinStore struct {
+	Path string
+	lock sync.Mutex
+}
+
+// NewFilePinStore - create PinStore using file path
+func NewFilePinStore(path string) *FilePinStore {
+	return &FilePinStore{Path: path}
+}
+
+func (f *FilePinStore) read() (map[string]string, error) {
+	var pins = make(map[string]string)
+
+	raw, err := ioutil.ReadFile(f.Path)
+	if err != nil {
+		// no pins stored yet
+		if os.IsNotExist(err) {
+			return pins, nil
+		}
+		return nil, err
+	}
+
+	if len(raw) == 0 {
+		return pins, nil
+	}
+
+	err = json.Unmarshal(raw, &pins)
+	if err != nil {
+		return nil, err
+	}
+	return pins, nil
+}
+
+// GetPin - get fingerprint for a host
+func (f *FilePinStore) GetPin(host string) (string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	pins, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	return pins[host], nil
+}
+
+// SetPin - pin fingerprint for a host
+func (f *FilePinStore) SetPin(host string, fingerprint string) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	pins, err := f.read()
+	if err != nil {
+		return err
+	}
+	pins[host] = fingerprint
+
+	raw, err := json.MarshalIndent(pins, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file and rename it to avoid a corrupted pin store
+	tmp, err := ioutil.TempFile(filepath.Dir(f.Path), filepath.Base(f.Path)+".")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(raw)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.Path)
+}
@@ -0,0 +1,287 @@
+package redfish
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// default time to wait for the requested power state
+	defaultPowerTimeout = 5 * time.Minute
+	// default time to wait for a graceful shutdown before the system is forced off
+	defaultGracefulShutdownTimeout = 2 * time.Minute
+	// default interval to poll the power state of the system
+	defaultPowerPollInterval = 5 * time.Second
+)
+
+// PowerOptions - options for power state transitions, unset fields use the defaults
+type PowerOptions struct {
+	// Timeout - time to wait for the requested power state, default 5 minutes
+	Timeout time.Duration
+	// GracefulShutdownTimeout - time to wait for a graceful shutdown before ForceOff is requested, default 2 minutes
+	GracefulShutdownTimeout time.Duration
+	// DisableForceOff - fail instead of requesting ForceOff if the system doesn't shut down gracefully
+	DisableForceOff bool
+	// PollInterval - interval to poll the power state of the system, default 5 seconds
+	PollInterval time.Duration
+	// Progress - optional callback, called after a reset has been requested and after each poll of the power state
+	Progress func(PowerProgress)
+}
+
+// PowerProgress - progress of a power state transition
+type PowerProgress struct {
+	// ResetType - reset type requested for the current step, e.g. GracefulShutdown, ForceOff or On
+	ResetType string
+	// TargetState - power state the current step waits for
+	TargetState string
+	// PowerState - power state reported by the system, empty if not polled yet
+	PowerState string
+	// Elapsed - time since the start of the current step
+	Elapsed time.Duration
+}
+
+func (o PowerOptions) timeout() time.Duration {
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return defaultPowerTimeout
+}
+
+func (o PowerOptions) gracefulShutdownTimeout() time.Duration {
+	if o.GracefulShutdownTimeout > 0 {
+		return o.GracefulShutdownTimeout
+	}
+	return defaultGracefulShutdownTimeout
+}
+
+func (o PowerOptions) pollInterval() time.Duration {
+	if o.PollInterval > 0 {
+		return o.PollInterval
+	}
+	return defaultPowerPollInterval
+}
+
+func (o PowerOptions) progress(p PowerProgress) {
+	if o.Progress != nil {
+		o.Progress(p)
+	}
+}
+
+// check if the reset type is supported by the system
+func (r *Redfish) supportsResetType(ctx context.Context, sd *SystemData, resetType string) (bool, error) {
+	if len(sd.allowedResetTypes) == 0 {
+		err := r.setAllowedResetTypes(ctx, sd)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	_, found := sd.allowedResetTypes[strings.ToLower(resetType)]
+	return found, nil
+}
+
+// get current power state of the system and update PowerState and ETag of sd
+func (r *Redfish) refreshPowerState(ctx context.Context, sd *SystemData) (string, error) {
+	current, err := r.GetSystemDataContext(ctx, *sd.SelfEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	sd.PowerState = current.PowerState
+	sd.ETag = current.ETag
+
+	if current.PowerState == nil {
+		return "", nil
+	}
+	return *current.PowerState, nil
+}
+
+// WaitForSystemPowerState - wait until the system reports the power state, a timeout of 0 waits forever.
+// PowerState and ETag of sd are updated with the values reported by the system.
+func (r *Redfish) WaitForSystemPowerState(sd *SystemData, state string, timeout time.Duration) error {
+	return r.WaitForSystemPowerStateContext(context.Background(), sd, state, timeout)
+}
+
+// WaitForSystemPowerStateContext - same as WaitForSystemPowerState but uses the supplied context for all HTTP requests
+func (r *Redfish) WaitForSystemPowerStateContext(ctx context.Context, sd *SystemData, state string, timeout time.Duration) error {
+	return r.waitForPowerState(ctx, sd, "", state, timeout, PowerOptions{})
+}
+
+func (r *Redfish) waitForPowerState(ctx context.Context, sd *SystemData, resetType string, state string, timeout time.Duration, opts PowerOptions) error {
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	if sd.SelfEndpoint == nil || *sd.SelfEndpoint == "" {
+		return errors.New("BUG: SelfEndpoint not set or empty in system data")
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	for {
+		current, err := r.refreshPowerState(ctx, sd)
+		if err != nil {
+			return err
+		}
+
+		opts.progress(PowerProgress{
+			ResetType:   resetType,
+			TargetState: state,
+			PowerState:  current,
+			Elapsed:     time.Since(start),
+		})
+
+		if strings.EqualFold(current, state) {
+			return nil
+		}
+
+		if r.Verbose {
+			r.logger().WithFields(LogFields{
+				"hostname":      r.Hostname,
+				"port":          r.Port,
+				"timeout":       r.Timeout,
+				"flavor":        r.Flavor,
+				"flavor_string": r.FlavorString,
+				"path":          *sd.SelfEndpoint,
+				"power_state":   current,
+				"target_state":  state,
+			}).Info("Waiting for power state")
+		}
+
+		timer := time.NewTimer(opts.pollInterval())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("System did not reach power state %s (current power state: %s): %w", state, current, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// request reset type and wait until the system reports the power state
+func (r *Redfish) transitionPowerState(ctx context.Context, sd *SystemData, resetType string, state string, timeout time.Duration, opts PowerOptions) error {
+	err := r.SetSystemPowerStateContext(ctx, sd, resetType)
+	if err != nil {
+		return err
+	}
+
+	opts.progress(PowerProgress{
+		ResetType:   resetType,
+		TargetState: state,
+	})
+
+	return r.waitForPowerState(ctx, sd, resetType, state, timeout, opts)
+}
+
+// PowerOffSystem - shut down the system gracefully and wait until it is powered off. If the system is still
+// powered on after GracefulShutdownTimeout (or doesn't support a graceful shutdown), ForceOff is requested unless
+// DisableForceOff is set.
+func (r *Redfish) PowerOffSystem(sd *SystemData, opts PowerOptions) error {
+	return r.PowerOffSystemContext(context.Background(), sd, opts)
+}
+
+// PowerOffSystemContext - same as PowerOffSystem but uses the supplied context for all HTTP requests
+func (r *Redfish) PowerOffSystemContext(ctx context.Context, sd *SystemData, opts PowerOptions) error {
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	if sd.SelfEndpoint == nil || *sd.SelfEndpoint == "" {
+		return errors.New("BUG: SelfEndpoint not set or empty in system data")
+	}
+
+	current, err := r.refreshPowerState(ctx, sd)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(current, "Off") {
+		return nil
+	}
+
+	graceful, err := r.supportsResetType(ctx, sd, "GracefulShutdown")
+	if err != nil {
+		return err
+	}
+
+	if graceful {
+		gracefulTimeout := opts.gracefulShutdownTimeout()
+		if opts.DisableForceOff {
+			gracefulTimeout = opts.timeout()
+		}
+
+		err = r.transitionPowerState(ctx, sd, "GracefulShutdown", "Off", gracefulTimeout, opts)
+		if err == nil {
+			return nil
+		}
+
+		// only escalate if the system didn't shut down in time
+		if opts.DisableForceOff || ctx.Err() != nil || !errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+
+		if r.Verbose {
+			r.logger().WithFields(LogFields{
+				"hostname":      r.Hostname,
+				"port":          r.Port,
+				"timeout":       r.Timeout,
+				"flavor":        r.Flavor,
+				"flavor_string": r.FlavorString,
+				"path":          *sd.SelfEndpoint,
+				"grace_period":  gracefulTimeout,
+			}).Warning("System did not shut down gracefully, forcing power off")
+		}
+	} else if opts.DisableForceOff {
+		return r.newNotSupportedError("System does not support graceful shutdown")
+	}
+
+	return r.transitionPowerState(ctx, sd, "ForceOff", "Off", opts.timeout(), opts)
+}
+
+// PowerOnSystem - power on the system and wait until it is powered on
+func (r *Redfish) PowerOnSystem(sd *SystemData, opts PowerOptions) error {
+	return r.PowerOnSystemContext(context.Background(), sd, opts)
+}
+
+// PowerOnSystemContext - same as PowerOnSystem but uses the supplied context for all HTTP requests
+func (r *Redfish) PowerOnSystemContext(ctx context.Context, sd *SystemData, opts PowerOptions) error {
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	if sd.SelfEndpoint == nil || *sd.SelfEndpoint == "" {
+		return errors.New("BUG: SelfEndpoint not set or empty in system data")
+	}
+
+	current, err := r.refreshPowerState(ctx, sd)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(current, "On") {
+		return nil
+	}
+
+	return r.transitionPowerState(ctx, sd, "On", "On", opts.timeout(), opts)
+}
+
+// PowerCycleSystem - power off the system (see PowerOffSystem), wait until it is powered off and power it on again
+func (r *Redfish) PowerCycleSystem(sd *SystemData, opts PowerOptions) error {
+	return r.PowerCycleSystemContext(context.Background(), sd, opts)
+}
+
+// PowerCycleSystemContext - same as PowerCycleSystem but uses the supplied context for all HTTP requests
+func (r *Redfish) PowerCycleSystemContext(ctx context.Context, sd *SystemData, opts PowerOptions) error {
+	err := r.PowerOffSystemContext(ctx, sd, opts)
+	if err != nil {
+		return err
+	}
+
+	return r.PowerOnSystemContext(ctx, sd, opts)
+}
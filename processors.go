@@ -0,0 +1,71 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GetSystemProcessors - get processors of a system
+func (r *Redfish) GetSystemProcessors(sd *SystemData) ([]*ProcessorData, error) {
+	return r.GetSystemProcessorsContext(context.Background(), sd)
+}
+
+// GetSystemProcessorsContext - same as GetSystemProcessors but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemProcessorsContext(ctx context.Context, sd *SystemData) ([]*ProcessorData, error) {
+	var result = make([]*ProcessorData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	if sd.Processors == nil || sd.Processors.ID == nil || *sd.Processors.ID == "" {
+		return result, r.newNotSupportedError("System does not provide a processor collection")
+	}
+
+	members, err := r.getCollectionContent(ctx, *sd.Processors.ID)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d ProcessorData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// MapProcessorsByID - map processors of a system by ID
+func (r *Redfish) MapProcessorsByID(sd *SystemData) (map[string]*ProcessorData, error) {
+	return r.MapProcessorsByIDContext(context.Background(), sd)
+}
+
+// MapProcessorsByIDContext - same as MapProcessorsByID but uses the supplied context for all HTTP requests
+func (r *Redfish) MapProcessorsByIDContext(ctx context.Context, sd *SystemData) (map[string]*ProcessorData, error) {
+	var result = make(map[string]*ProcessorData)
+
+	pl, err := r.GetSystemProcessorsContext(ctx, sd)
+	if err != nil {
+		return result, err
+	}
+
+	for _, p := range pl {
+		// should NEVER happen
+		if p.ID == nil {
+			return result, fmt.Errorf("BUG: No Id found for Processor at %s", *p.SelfEndpoint)
+		}
+
+		result[*p.ID] = p
+	}
+
+	return result, nil
+}
@@ -0,0 +1,249 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// QueryOptions - OData query parameters for GET requests, unset fields are not added to the request
+type QueryOptions struct {
+	// Expand - value of $expand, e.g. "." to expand all subordinate resources or "*($levels=2)"
+	Expand string
+	// Select - properties to return
+	Select []string
+	// Filter - value of $filter, e.g. "Severity eq 'Critical'"
+	Filter string
+	// Top - maximal number of members to return, 0 for no limit
+	Top int
+	// Skip - number of members to skip
+	Skip int
+}
+
+// escape query value, only characters not allowed in a query component (RFC 3986) and the separators & and + are
+// escaped because service processors don't accept e.g. *(%24levels%3D2) as $expand value. Spaces are escaped as %20
+// instead of + because not all service processors decode +.
+func queryEscape(value string) string {
+	var result strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || strings.IndexByte("-._~!$'()*,;=:@/", c) >= 0 {
+			result.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&result, "%%%02X", c)
+	}
+
+	return result.String()
+}
+
+// Endpoint - add the query parameters to endpoint
+//
+// Note: The $ of the parameter names is never escaped because some service processors don't accept it.
+func (q QueryOptions) Endpoint(endpoint string) string {
+	var params = make([]string, 0)
+
+	if q.Expand != "" {
+		params = append(params, "$expand="+queryEscape(q.Expand))
+	}
+	if len(q.Select) > 0 {
+		var sel = make([]string, 0, len(q.Select))
+		for _, s := range q.Select {
+			sel = append(sel, queryEscape(s))
+		}
+		params = append(params, "$select="+strings.Join(sel, ","))
+	}
+	if q.Filter != "" {
+		params = append(params, "$filter="+queryEscape(q.Filter))
+	}
+	if q.Top > 0 {
+		params = append(params, fmt.Sprintf("$top=%d", q.Top))
+	}
+	if q.Skip > 0 {
+		params = append(params, fmt.Sprintf("$skip=%d", q.Skip))
+	}
+
+	if len(params) == 0 {
+		return endpoint
+	}
+
+	if strings.Contains(endpoint, "?") {
+		return endpoint + "&" + strings.Join(params, "&")
+	}
+	return endpoint + "?" + strings.Join(params, "&")
+}
+
+// check if query parameters are supported as reported by ProtocolFeaturesSupported of the service root
+func (r *Redfish) checkQueryOptions(q QueryOptions) error {
+	pf := r.ProtocolFeatures
+	if pf == nil {
+		pf = &ProtocolFeaturesSupported{}
+	}
+
+	if q.Expand != "" && !r.supportsExpand() {
+		return r.newNotSupportedError("Service processor does not support the $expand query parameter")
+	}
+	if len(q.Select) > 0 && !pf.SelectQuery {
+		return r.newNotSupportedError("Service processor does not support the $select query parameter")
+	}
+	if q.Filter != "" && !pf.FilterQuery {
+		return r.newNotSupportedError("Service processor does not support the $filter query parameter")
+	}
+	if (q.Top > 0 || q.Skip > 0) && !pf.TopSkipQuery {
+		return r.newNotSupportedError("Service processor does not support the $top and $skip query parameters")
+	}
+	return nil
+}
+
+func (r *Redfish) supportsExpand() bool {
+	if r.ProtocolFeatures == nil || r.ProtocolFeatures.ExpandQuery == nil {
+		return false
+	}
+	return r.ProtocolFeatures.ExpandQuery.ExpandAll || r.ProtocolFeatures.ExpandQuery.NoLinks
+}
+
+// $expand value to expand the members of a collection
+func (r *Redfish) expandMembersQuery() string {
+	// "." expands all subordinate resources but not links, Members are not part of Links
+	if r.ProtocolFeatures.ExpandQuery.NoLinks {
+		return "."
+	}
+	return "*"
+}
+
+// GetResourceWithQuery - same as GetResource but adds the OData query parameters,
+// returns ErrNotSupported if the service processor doesn't report support for the query parameters
+func (r *Redfish) GetResourceWithQuery(endpoint string, q QueryOptions) (map[string]interface{}, error) {
+	return r.GetResourceWithQueryContext(context.Background(), endpoint, q)
+}
+
+// GetResourceWithQueryContext - same as GetResourceWithQuery but uses the supplied context for all HTTP requests
+func (r *Redfish) GetResourceWithQueryContext(ctx context.Context, endpoint string, q QueryOptions) (map[string]interface{}, error) {
+	var result map[string]interface{}
+
+	err := r.GetResourceIntoWithQueryContext(ctx, endpoint, q, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetResourceIntoWithQuery - same as GetResourceInto but adds the OData query parameters,
+// returns ErrNotSupported if the service processor doesn't report support for the query parameters
+func (r *Redfish) GetResourceIntoWithQuery(endpoint string, q QueryOptions, v interface{}) error {
+	return r.GetResourceIntoWithQueryContext(context.Background(), endpoint, q, v)
+}
+
+// GetResourceIntoWithQueryContext - same as GetResourceIntoWithQuery but uses the supplied context for all HTTP requests
+func (r *Redfish) GetResourceIntoWithQueryContext(ctx context.Context, endpoint string, q QueryOptions, v interface{}) error {
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	err := r.checkQueryOptions(q)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.getJSON(ctx, q.Endpoint(endpoint), v)
+	return err
+}
+
+// expandCollection - get all members of a collection by a single request (per page) using $expand,
+// returns nil if the service processor doesn't support $expand or ignored the query parameter
+func (r *Redfish) expandCollection(ctx context.Context, endpoint string) ([]json.RawMessage, error) {
+	var result = make([]json.RawMessage, 0)
+	var visited = make(map[string]bool)
+
+	if !r.supportsExpand() {
+		return nil, nil
+	}
+
+	next := QueryOptions{Expand: r.expandMembersQuery()}.Endpoint(endpoint)
+	for next != "" {
+		var collection struct {
+			Members  []json.RawMessage `json:"Members"`
+			NextLink *string           `json:"Members@odata.nextLink"`
+		}
+
+		if visited[next] {
+			return nil, fmt.Errorf("BUG: Members@odata.nextLink of collection points to already fetched page %s", next)
+		}
+		visited[next] = true
+
+		if r.Verbose {
+			r.logger().WithFields(LogFields{
+				"hostname":           r.Hostname,
+				"port":               r.Port,
+				"timeout":            r.Timeout,
+				"flavor":             r.Flavor,
+				"flavor_string":      r.FlavorString,
+				"path":               next,
+				"method":             "GET",
+				"additional_headers": nil,
+				"use_basic_auth":     r.UseBasicAuth,
+			}).Info("Requesting expanded collection")
+		}
+		response, err := r.httpRequest(ctx, next, "GET", nil, nil, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if response.StatusCode != http.StatusOK {
+			return nil, r.newHTTPError("GET", response, http.StatusOK)
+		}
+
+		err = json.Unmarshal(response.Content, &collection)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range collection.Members {
+			var m map[string]json.RawMessage
+
+			err = json.Unmarshal(member, &m)
+			if err != nil {
+				return nil, err
+			}
+
+			// some service processors report support for $expand but only return the references
+			if len(m) <= 1 {
+				return nil, nil
+			}
+
+			result = append(result, member)
+		}
+
+		next = ""
+		if collection.NextLink != nil {
+			next = *collection.NextLink
+		}
+	}
+
+	return result, nil
+}
+
+// decode expanded member and return its endpoint
+func decodeExpandedMember(raw json.RawMessage, v interface{}) (string, error) {
+	var odata OData
+
+	err := json.Unmarshal(raw, &odata)
+	if err != nil {
+		return "", err
+	}
+
+	if odata.ID == nil || *odata.ID == "" {
+		return "", fmt.Errorf("BUG: Expanded collection member without @odata.id found")
+	}
+
+	err = json.Unmarshal(raw, v)
+	if err != nil {
+		return "", err
+	}
+
+	return *odata.ID, nil
+}
@@ -0,0 +1,203 @@
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// fetch endpoint and decode the JSON reply into v
+func (r *Redfish) getJSON(ctx context.Context, endpoint string, v interface{}) (HTTPResult, error) {
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":           r.Hostname,
+			"port":               r.Port,
+			"timeout":            r.Timeout,
+			"flavor":             r.Flavor,
+			"flavor_string":      r.FlavorString,
+			"path":               endpoint,
+			"method":             "GET",
+			"additional_headers": nil,
+			"use_basic_auth":     r.UseBasicAuth,
+		}).Info("Requesting resource")
+	}
+	response, err := r.httpRequest(ctx, endpoint, "GET", nil, nil, false)
+	if err != nil {
+		return response, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return response, r.newHTTPError("GET", response, http.StatusOK)
+	}
+
+	err = json.Unmarshal(response.Content, v)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+// send JSON encoded payload to endpoint, every 2xx status code is considered a success
+func (r *Redfish) sendJSON(ctx context.Context, method string, endpoint string, header *map[string]string, payload interface{}) (HTTPResult, error) {
+	var reader io.Reader
+	var raw []byte
+	var err error
+
+	if payload != nil {
+		raw, err = json.Marshal(payload)
+		if err != nil {
+			return HTTPResult{}, err
+		}
+		reader = bytes.NewReader(raw)
+	}
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":           r.Hostname,
+			"port":               r.Port,
+			"timeout":            r.Timeout,
+			"flavor":             r.Flavor,
+			"flavor_string":      r.FlavorString,
+			"path":               endpoint,
+			"method":             method,
+			"additional_headers": header,
+			"use_basic_auth":     r.UseBasicAuth,
+		}).Info("Sending request to resource")
+	}
+	if r.Debug {
+		r.logger().WithFields(LogFields{
+			"hostname":           r.Hostname,
+			"port":               r.Port,
+			"timeout":            r.Timeout,
+			"flavor":             r.Flavor,
+			"flavor_string":      r.FlavorString,
+			"path":               endpoint,
+			"method":             method,
+			"additional_headers": header,
+			"use_basic_auth":     r.UseBasicAuth,
+			"payload":            redactPayload(string(raw)),
+		}).Debug("Sending request to resource")
+	}
+	response, err := r.httpRequest(ctx, endpoint, method, header, reader, false)
+	if err != nil {
+		return response, err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response, r.newHTTPError(method, response)
+	}
+
+	return response, nil
+}
+
+// GetResource - get an arbitrary resource, e.g. from an @odata.id reference, as generic JSON object
+func (r *Redfish) GetResource(endpoint string) (map[string]interface{}, error) {
+	return r.GetResourceContext(context.Background(), endpoint)
+}
+
+// GetResourceContext - same as GetResource but uses the supplied context for all HTTP requests
+func (r *Redfish) GetResourceContext(ctx context.Context, endpoint string) (map[string]interface{}, error) {
+	var result map[string]interface{}
+
+	err := r.GetResourceIntoContext(ctx, endpoint, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetResourceInto - get an arbitrary resource and decode it into v, which must be a pointer
+func (r *Redfish) GetResourceInto(endpoint string, v interface{}) error {
+	return r.GetResourceIntoContext(context.Background(), endpoint, v)
+}
+
+// GetResourceIntoContext - same as GetResourceInto but uses the supplied context for all HTTP requests
+func (r *Redfish) GetResourceIntoContext(ctx context.Context, endpoint string, v interface{}) error {
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	_, err := r.getJSON(ctx, endpoint, v)
+	return err
+}
+
+// PatchResource - send a PATCH request with JSON encoded payload to an arbitrary resource
+//
+// The payload is encoded by encoding/json, use json.RawMessage to send pre-encoded data.
+func (r *Redfish) PatchResource(endpoint string, payload interface{}) (HTTPResult, error) {
+	return r.PatchResourceContext(context.Background(), endpoint, payload)
+}
+
+// PatchResourceContext - same as PatchResource but uses the supplied context for all HTTP requests
+func (r *Redfish) PatchResourceContext(ctx context.Context, endpoint string, payload interface{}) (HTTPResult, error) {
+	if !r.isAuthenticated() {
+		return HTTPResult{}, ErrNotAuthenticated
+	}
+
+	return r.sendJSON(ctx, "PATCH", endpoint, nil, payload)
+}
+
+// PatchResourceIfMatch - same as PatchResource but the request is only processed if the entity tag of the resource
+// still matches etag, otherwise an error matching ErrPreconditionFailed is returned
+func (r *Redfish) PatchResourceIfMatch(endpoint string, etag string, payload interface{}) (HTTPResult, error) {
+	return r.PatchResourceIfMatchContext(context.Background(), endpoint, etag, payload)
+}
+
+// PatchResourceIfMatchContext - same as PatchResourceIfMatch but uses the supplied context for all HTTP requests
+func (r *Redfish) PatchResourceIfMatchContext(ctx context.Context, endpoint string, etag string, payload interface{}) (HTTPResult, error) {
+	if !r.isAuthenticated() {
+		return HTTPResult{}, ErrNotAuthenticated
+	}
+
+	return r.sendJSON(ctx, "PATCH", endpoint, ifMatchHeader(etag), payload)
+}
+
+// PostResource - send a POST request with JSON encoded payload to an arbitrary resource or action target
+//
+// The payload is encoded by encoding/json, use json.RawMessage to send pre-encoded data.
+func (r *Redfish) PostResource(endpoint string, payload interface{}) (HTTPResult, error) {
+	return r.PostResourceContext(context.Background(), endpoint, payload)
+}
+
+// PostResourceContext - same as PostResource but uses the supplied context for all HTTP requests
+func (r *Redfish) PostResourceContext(ctx context.Context, endpoint string, payload interface{}) (HTTPResult, error) {
+	if !r.isAuthenticated() {
+		return HTTPResult{}, ErrNotAuthenticated
+	}
+
+	return r.sendJSON(ctx, "POST", endpoint, nil, payload)
+}
+
+// PutResource - send a PUT request with JSON encoded payload to an arbitrary resource
+//
+// The payload is encoded by encoding/json, use json.RawMessage to send pre-encoded data.
+func (r *Redfish) PutResource(endpoint string, payload interface{}) (HTTPResult, error) {
+	return r.PutResourceContext(context.Background(), endpoint, payload)
+}
+
+// PutResourceContext - same as PutResource but uses the supplied context for all HTTP requests
+func (r *Redfish) PutResourceContext(ctx context.Context, endpoint string, payload interface{}) (HTTPResult, error) {
+	if !r.isAuthenticated() {
+		return HTTPResult{}, ErrNotAuthenticated
+	}
+
+	return r.sendJSON(ctx, "PUT", endpoint, nil, payload)
+}
+
+// DeleteResource - send a DELETE request to an arbitrary resource
+func (r *Redfish) DeleteResource(endpoint string) (HTTPResult, error) {
+	return r.DeleteResourceContext(context.Background(), endpoint)
+}
+
+// DeleteResourceContext - same as DeleteResource but uses the supplied context for all HTTP requests
+func (r *Redfish) DeleteResourceContext(ctx context.Context, endpoint string) (HTTPResult, error) {
+	if !r.isAuthenticated() {
+		return HTTPResult{}, ErrNotAuthenticated
+	}
+
+	return r.sendJSON(ctx, "DELETE", endpoint, nil, nil)
+}
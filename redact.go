@@ -0,0 +1,110 @@
+package redfish
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// replacement for sensitive data in log output
+const redactedValue = "[redacted]"
+
+// JSON properties containing one of these strings (compared in lower case) hold sensitive data
+var sensitiveJSONProperties = []string{
+	"password",
+	"passphrase",
+	"secret",
+	"token",
+	"licensekey",
+	"privatekey",
+}
+
+// HTTP headers holding credentials
+var sensitiveHTTPHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Auth-Token",
+}
+
+var privateKeyPEMRegexp = regexp.MustCompile(`(?s)-----BEGIN ([A-Z0-9 ]*)PRIVATE KEY-----.*?(-----END ([A-Z0-9 ]*)PRIVATE KEY-----|$)`)
+
+func isSensitiveJSONProperty(name string) bool {
+	lname := strings.ToLower(name)
+	for _, s := range sensitiveJSONProperties {
+		if strings.Contains(lname, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, sub := range v {
+			if isSensitiveJSONProperty(key) && sub != nil {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactJSONValue(sub)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, sub := range v {
+			v[i] = redactJSONValue(sub)
+		}
+		return v
+	case string:
+		return redactPrivateKeys(v)
+	default:
+		return v
+	}
+}
+
+func redactPrivateKeys(s string) string {
+	return privateKeyPEMRegexp.ReplaceAllString(s, redactedValue)
+}
+
+// redactPayload - mask passwords, tokens, license keys and private keys in JSON data before it is logged,
+// non-JSON data is only checked for PEM encoded private keys
+func redactPayload(payload string) string {
+	var parsed interface{}
+
+	if payload == "" {
+		return payload
+	}
+
+	err := json.Unmarshal([]byte(payload), &parsed)
+	if err != nil {
+		return redactPrivateKeys(payload)
+	}
+
+	raw, err := json.Marshal(redactJSONValue(parsed))
+	if err != nil {
+		return redactedValue
+	}
+	return string(raw)
+}
+
+// redactHeader - copy of HTTP headers with credentials masked
+func redactHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	result := header.Clone()
+	for _, name := range sensitiveHTTPHeaders {
+		values := result.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+
+		result.Del(name)
+		for range values {
+			result.Add(name, redactedValue)
+		}
+	}
+	return result
+}
@@ -1,9 +1,9 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 	"strings"
 )
@@ -58,19 +58,24 @@ func (r *Redfish) getManagerResetTarget(mgr *ManagerData) (string, error) {
 
 // ResetSP - reset service processor
 func (r *Redfish) ResetSP() error {
-	err := r.GetVendorFlavor()
+	return r.ResetSPContext(context.Background())
+}
+
+// ResetSPContext - same as ResetSP but uses the supplied context for all HTTP requests
+func (r *Redfish) ResetSPContext(ctx context.Context) error {
+	err := r.GetVendorFlavorContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	// get list of Manager endpoint
-	mgrList, err := r.GetManagers()
+	mgrList, err := r.GetManagersContext(ctx)
 	if err != nil {
 		return err
 	}
 
 	// pick the first entry
-	mgr0, err := r.GetManagerData(mgrList[0])
+	mgr0, err := r.GetManagerDataContext(ctx, mgrList[0])
 	if err != nil {
 		return err
 	}
@@ -82,7 +87,7 @@ func (r *Redfish) ResetSP() error {
 
 	spResetPayload := "{ \"ResetType\": \"ForceRestart\" }"
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -91,11 +96,11 @@ func (r *Redfish) ResetSP() error {
 			"path":               spResetTarget,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting service processor restart")
 	}
 	if r.Debug {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -104,17 +109,17 @@ func (r *Redfish) ResetSP() error {
 			"path":               spResetTarget,
 			"method":             "POST",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
-			"payload":            spResetPayload,
+			"use_basic_auth":     r.UseBasicAuth,
+			"payload":            redactPayload(spResetPayload),
 		}).Debug("Requesting service processor restart")
 	}
-	response, err := r.httpRequest(spResetTarget, "POST", nil, strings.NewReader(spResetPayload), false)
+	response, err := r.httpRequest(ctx, spResetTarget, "POST", nil, strings.NewReader(spResetPayload), false)
 	if err != nil {
 		return err
 	}
 
 	if response.StatusCode != http.StatusOK {
-		return fmt.Errorf("HTTP POST to %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return r.newHTTPError("POST", response, http.StatusOK)
 	}
 
 	return nil
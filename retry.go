@@ -0,0 +1,167 @@
+package redfish
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy - retry policy for transient errors like busy or unresponsive service processors
+type RetryPolicy struct {
+	// MaxAttempts - maximal number of attempts, including the first request
+	MaxAttempts int
+	// InitialBackoff - time to wait before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff - upper limit for the time to wait between retries, also applied to Retry-After (0 means no limit)
+	MaxBackoff time.Duration
+	// Multiplier - factor to increase the backoff after each attempt, 2 is used if not set
+	Multiplier float64
+	// Jitter - randomisation factor (0.0 - 1.0) of the backoff to avoid synchronised retries
+	Jitter float64
+	// RetryStatusCodes - HTTP status codes to retry, DefaultRetryStatusCodes are used if not set
+	RetryStatusCodes []int
+	// RetryNonIdempotent - also retry non-idempotent requests (PATCH, POST, PUT, DELETE).
+	// By default only GET and HEAD requests will be retried.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryStatusCodes - HTTP status codes indicating a transient error
+var DefaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// DefaultRetryPolicy - retry policy with sensible defaults, used if RetryPolicy of the Redfish object is not set.
+// Only GET and HEAD requests are retried.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 1 * time.Second,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// retry policy of the Redfish object, DefaultRetryPolicy if not set
+func (r *Redfish) retryPolicy() *RetryPolicy {
+	if r.RetryPolicy != nil {
+		return r.RetryPolicy
+	}
+	return DefaultRetryPolicy()
+}
+
+// check if retries are enabled for this HTTP method
+func (p *RetryPolicy) appliesTo(method string) bool {
+	if p == nil || p.MaxAttempts <= 1 {
+		return false
+	}
+
+	switch method {
+	case "GET", "HEAD":
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// check if the status code is considered as a transient error
+func (p *RetryPolicy) retryStatus(code int) bool {
+	codes := p.RetryStatusCodes
+	if len(codes) == 0 {
+		codes = DefaultRetryStatusCodes
+	}
+
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// check if the error is considered as a transient network error
+func retryError(err error) bool {
+	var netErr net.Error
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return false
+}
+
+// time to wait before the next attempt
+func (p *RetryPolicy) backoff(attempt int, result HTTPResult) time.Duration {
+	// the server knows best
+	if result.Header != nil {
+		retryAfter, found := parseRetryAfter(result.Header.Get("Retry-After"))
+		if found {
+			// but don't stall the caller for hours
+			if p.MaxBackoff > 0 && retryAfter > p.MaxBackoff {
+				return p.MaxBackoff
+			}
+			return retryAfter
+		}
+	}
+
+	mult := p.Multiplier
+	if mult <= 0 {
+		mult = 2
+	}
+
+	delay := float64(p.InitialBackoff) * math.Pow(mult, float64(attempt-1))
+	if p.MaxBackoff > 0 && delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	if delay < 0 {
+		return 0
+	}
+	return time.Duration(delay)
+}
+
+// parse value of the Retry-After header, it can be the delay in seconds or a HTTP date (see RFC 7231, section 7.1.3)
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	secs, err := strconv.Atoi(value)
+	if err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+
+	when, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	delay := time.Until(when)
+	if delay < 0 {
+		delay = 0
+	}
+	return delay, true
+}
@@ -1,105 +1,103 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 )
 
 // GetRoles - get array of roles and their endpoints
 func (r *Redfish) GetRoles() ([]string, error) {
-	var accsvc AccountService
-	var roles OData
-	var result = make([]string, 0)
+	return r.GetRolesContext(context.Background())
+}
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return result, errors.New("No authentication token found, is the session setup correctly?")
-	}
+// GetRolesContext - same as GetRoles but uses the supplied context for all HTTP requests
+func (r *Redfish) GetRolesContext(ctx context.Context) ([]string, error) {
+	var result = make([]string, 0)
 
-	if r.Verbose {
-		log.WithFields(log.Fields{
-			"hostname":           r.Hostname,
-			"port":               r.Port,
-			"timeout":            r.Timeout,
-			"flavor":             r.Flavor,
-			"flavor_string":      r.FlavorString,
-			"path":               r.AccountService,
-			"method":             "GET",
-			"additional_headers": nil,
-			"use_basic_auth":     false,
-		}).Info("Requesting path for account roles")
-	}
-	response, err := r.httpRequest(r.AccountService, "GET", nil, nil, false)
+	endpoint, err := r.getRolesEndpoint(ctx)
 	if err != nil {
 		return result, err
 	}
 
-	raw := response.Content
-
-	if response.StatusCode != http.StatusOK {
-		return result, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+	// Some managementboards (e.g. HPE iLO) don't use roles but an internal ("Oem") privilege map instead
+	if endpoint == "" {
+		return result, nil
 	}
 
-	err = json.Unmarshal(raw, &accsvc)
+	result, err = r.GetCollectionMembersContext(ctx, endpoint)
 	if err != nil {
 		return result, err
 	}
 
-	// Some managementboards (e.g. HPE iLO) don't use roles but an internal ("Oem") privilege map instead
-	if accsvc.RolesEndpoint == nil {
-		return result, nil
+	if len(result) == 0 {
+		return result, fmt.Errorf("BUG: Missing or empty Members attribute in Roles")
+	}
+	return result, nil
+}
+
+// get endpoint of the roles collection from the account service, empty if the service processor doesn't use roles
+func (r *Redfish) getRolesEndpoint(ctx context.Context) (string, error) {
+	var accsvc AccountService
+
+	if !r.isAuthenticated() {
+		return "", ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
 			"flavor":             r.Flavor,
 			"flavor_string":      r.FlavorString,
-			"path":               *accsvc.RolesEndpoint.ID,
+			"path":               r.AccountService,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
-		}).Info("Requesting account roles")
+			"use_basic_auth":     r.UseBasicAuth,
+		}).Info("Requesting path for account roles")
 	}
-	response, err = r.httpRequest(*accsvc.RolesEndpoint.ID, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, r.AccountService, "GET", nil, nil, false)
 	if err != nil {
-		return result, err
+		return "", err
 	}
-	raw = response.Content
+
+	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return result, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return "", r.newHTTPError("GET", response, http.StatusOK)
 	}
 
-	err = json.Unmarshal(raw, &roles)
+	err = json.Unmarshal(raw, &accsvc)
 	if err != nil {
-		return result, err
+		return "", err
 	}
 
-	if len(roles.Members) == 0 {
-		return result, fmt.Errorf("BUG: Missing or empty Members attribute in Roles")
+	// Some managementboards (e.g. HPE iLO) don't use roles but an internal ("Oem") privilege map instead
+	if accsvc.RolesEndpoint == nil || accsvc.RolesEndpoint.ID == nil {
+		return "", nil
 	}
 
-	for _, r := range roles.Members {
-		result = append(result, *r.ID)
-	}
-	return result, nil
+	return *accsvc.RolesEndpoint.ID, nil
 }
 
 // GetRoleData - get role data for a particular role
 func (r *Redfish) GetRoleData(roleEndpoint string) (*RoleData, error) {
+	return r.GetRoleDataContext(context.Background(), roleEndpoint)
+}
+
+// GetRoleDataContext - same as GetRoleData but uses the supplied context for all HTTP requests
+func (r *Redfish) GetRoleDataContext(ctx context.Context, roleEndpoint string) (*RoleData, error) {
 	var result RoleData
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return nil, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -108,10 +106,10 @@ func (r *Redfish) GetRoleData(roleEndpoint string) (*RoleData, error) {
 			"path":               roleEndpoint,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting role information")
 	}
-	response, err := r.httpRequest(roleEndpoint, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, roleEndpoint, "GET", nil, nil, false)
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +117,7 @@ func (r *Redfish) GetRoleData(roleEndpoint string) (*RoleData, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return nil, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &result)
@@ -128,24 +126,60 @@ func (r *Redfish) GetRoleData(roleEndpoint string) (*RoleData, error) {
 	}
 
 	result.SelfEndpoint = &roleEndpoint
+	result.ETag = etagFromResult(response, result.ETag)
 	return &result, nil
 }
 
-// MapRolesByName - map roles by name
-func (r *Redfish) MapRolesByName() (map[string]*RoleData, error) {
-	var result = make(map[string]*RoleData)
+// get data of all roles, by a single request if the service processor supports $expand
+func (r *Redfish) getAllRoleData(ctx context.Context) ([]*RoleData, error) {
+	var result = make([]*RoleData, 0)
 
-	rll, err := r.GetRoles()
+	endpoint, err := r.getRolesEndpoint(ctx)
 	if err != nil {
 		return result, err
 	}
 
-	for _, ro := range rll {
-		rl, err := r.GetRoleData(ro)
+	// Some managementboards (e.g. HPE iLO) don't use roles but an internal ("Oem") privilege map instead
+	if endpoint == "" {
+		return result, nil
+	}
+
+	members, err := r.getCollectionContent(ctx, endpoint)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d RoleData
+
+		err = json.Unmarshal(members[i].content, &d)
 		if err != nil {
 			return result, err
 		}
 
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// MapRolesByName - map roles by name
+func (r *Redfish) MapRolesByName() (map[string]*RoleData, error) {
+	return r.MapRolesByNameContext(context.Background())
+}
+
+// MapRolesByNameContext - same as MapRolesByName but uses the supplied context for all HTTP requests
+func (r *Redfish) MapRolesByNameContext(ctx context.Context) (map[string]*RoleData, error) {
+	var result = make(map[string]*RoleData)
+
+	dl, err := r.getAllRoleData(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, rl := range dl {
 		// should NEVER happen
 		if rl.Name == nil {
 			return result, errors.New("No Name found or Name is null")
@@ -159,19 +193,19 @@ func (r *Redfish) MapRolesByName() (map[string]*RoleData, error) {
 
 // MapRolesByID - map roles by ID
 func (r *Redfish) MapRolesByID() (map[string]*RoleData, error) {
+	return r.MapRolesByIDContext(context.Background())
+}
+
+// MapRolesByIDContext - same as MapRolesByID but uses the supplied context for all HTTP requests
+func (r *Redfish) MapRolesByIDContext(ctx context.Context) (map[string]*RoleData, error) {
 	var result = make(map[string]*RoleData)
 
-	rll, err := r.GetRoles()
+	dl, err := r.getAllRoleData(ctx)
 	if err != nil {
 		return result, err
 	}
 
-	for _, ro := range rll {
-		rl, err := r.GetRoleData(ro)
-		if err != nil {
-			return result, err
-		}
-
+	for _, rl := range dl {
 		// should NEVER happen
 		if rl.ID == nil {
 			return result, errors.New("No Id found or Id is null")
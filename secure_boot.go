@@ -0,0 +1,193 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// reset types of #SecureBoot.ResetKeys defined by the standard, used if the system doesn't report allowable values
+var defaultResetKeysTypeValues = []string{"ResetAllKeysToDefault", "DeleteAllKeys", "DeletePK"}
+
+// GetSystemSecureBoot - get UEFI Secure Boot state of a system
+func (r *Redfish) GetSystemSecureBoot(sd *SystemData) (*SecureBootData, error) {
+	return r.GetSystemSecureBootContext(context.Background(), sd)
+}
+
+// GetSystemSecureBootContext - same as GetSystemSecureBoot but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemSecureBootContext(ctx context.Context, sd *SystemData) (*SecureBootData, error) {
+	var result SecureBootData
+
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	if sd.SecureBoot == nil || sd.SecureBoot.ID == nil || *sd.SecureBoot.ID == "" {
+		return nil, r.newNotSupportedError("System does not provide a Secure Boot resource")
+	}
+	endpoint := *sd.SecureBoot.ID
+
+	response, err := r.getJSON(ctx, endpoint, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	result.SelfEndpoint = &endpoint
+	result.ETag = etagFromResult(response, result.ETag)
+	return &result, nil
+}
+
+// SetSecureBootEnable - enable or disable UEFI Secure Boot, the change takes effect on the next reboot of the system
+func (r *Redfish) SetSecureBootEnable(sb *SecureBootData, enable bool) error {
+	return r.SetSecureBootEnableContext(context.Background(), sb, enable)
+}
+
+// SetSecureBootEnableContext - same as SetSecureBootEnable but uses the supplied context for all HTTP requests
+func (r *Redfish) SetSecureBootEnableContext(ctx context.Context, sb *SecureBootData, enable bool) error {
+	var header *map[string]string
+
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	if sb.SelfEndpoint == nil || *sb.SelfEndpoint == "" {
+		return errors.New("BUG: SelfEndpoint not set or empty in Secure Boot data")
+	}
+
+	if sb.ETag != nil {
+		header = ifMatchHeader(*sb.ETag)
+	}
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":      r.Hostname,
+			"port":          r.Port,
+			"timeout":       r.Timeout,
+			"flavor":        r.Flavor,
+			"flavor_string": r.FlavorString,
+			"path":          *sb.SelfEndpoint,
+			"enable":        enable,
+		}).Info("Setting Secure Boot state")
+	}
+
+	_, err := r.sendJSON(ctx, "PATCH", *sb.SelfEndpoint, header, secureBootEnablePayload{SecureBootEnable: enable})
+	return err
+}
+
+// ResetSecureBootKeys - reset the UEFI Secure Boot keys, resetType is one of the reset types supported by the system
+// (e.g. ResetAllKeysToDefault, DeleteAllKeys or DeletePK)
+func (r *Redfish) ResetSecureBootKeys(sb *SecureBootData, resetType string) error {
+	return r.ResetSecureBootKeysContext(context.Background(), sb, resetType)
+}
+
+// ResetSecureBootKeysContext - same as ResetSecureBootKeys but uses the supplied context for all HTTP requests
+func (r *Redfish) ResetSecureBootKeysContext(ctx context.Context, sb *SecureBootData, resetType string) error {
+	if !r.isAuthenticated() {
+		return ErrNotAuthenticated
+	}
+
+	if sb.Actions == nil || sb.Actions.ResetKeys == nil || sb.Actions.ResetKeys.Target == "" {
+		return r.newNotSupportedError("System does not support resetting the Secure Boot keys")
+	}
+
+	allowed := sb.Actions.ResetKeys.ResetKeysTypeValues
+	if len(allowed) == 0 {
+		allowed = defaultResetKeysTypeValues
+	}
+
+	_resetType, err := allowableValue("ResetKeysType", resetType, allowed)
+	if err != nil {
+		return err
+	}
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":        r.Hostname,
+			"port":            r.Port,
+			"timeout":         r.Timeout,
+			"flavor":          r.Flavor,
+			"flavor_string":   r.FlavorString,
+			"path":            sb.Actions.ResetKeys.Target,
+			"reset_keys_type": _resetType,
+		}).Info("Resetting Secure Boot keys")
+	}
+
+	_, err = r.sendJSON(ctx, "POST", sb.Actions.ResetKeys.Target, nil, secureBootResetKeysPayload{ResetKeysType: _resetType})
+	return err
+}
+
+// GetSecureBootDatabases - get UEFI Secure Boot key databases of a system
+func (r *Redfish) GetSecureBootDatabases(sb *SecureBootData) ([]*SecureBootDatabaseData, error) {
+	return r.GetSecureBootDatabasesContext(context.Background(), sb)
+}
+
+// GetSecureBootDatabasesContext - same as GetSecureBootDatabases but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSecureBootDatabasesContext(ctx context.Context, sb *SecureBootData) ([]*SecureBootDatabaseData, error) {
+	var result = make([]*SecureBootDatabaseData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	if sb.SecureBootDatabases == nil || sb.SecureBootDatabases.ID == nil || *sb.SecureBootDatabases.ID == "" {
+		return result, r.newNotSupportedError("System does not provide Secure Boot key databases")
+	}
+
+	members, err := r.getCollectionContent(ctx, *sb.SecureBootDatabases.ID)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d SecureBootDatabaseData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// GetSecureBootDatabaseCertificates - get certificates stored in a UEFI Secure Boot key database
+func (r *Redfish) GetSecureBootDatabaseCertificates(db *SecureBootDatabaseData) ([]*CertificateData, error) {
+	return r.GetSecureBootDatabaseCertificatesContext(context.Background(), db)
+}
+
+// GetSecureBootDatabaseCertificatesContext - same as GetSecureBootDatabaseCertificates but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSecureBootDatabaseCertificatesContext(ctx context.Context, db *SecureBootDatabaseData) ([]*CertificateData, error) {
+	var result = make([]*CertificateData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	if db.Certificates == nil || db.Certificates.ID == nil || *db.Certificates.ID == "" {
+		return result, r.newNotSupportedError("Secure Boot key database does not provide certificates")
+	}
+
+	members, err := r.getCollectionContent(ctx, *db.Certificates.ID)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d CertificateData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,247 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Default session timeout as defined by the Redfish standard, used if the service processor doesn't report it
+const defaultSessionTimeout = 30 * time.Minute
+
+// minimal interval between two keep-alive requests
+const minSessionKeepAliveInterval = 10 * time.Second
+
+// get current authentication token, empty string if no session has been established
+func (r *Redfish) getAuthToken() string {
+	r.authLock.RLock()
+	defer r.authLock.RUnlock()
+
+	if r.AuthToken == nil {
+		return ""
+	}
+	return *r.AuthToken
+}
+
+// check if requests can be authenticated, either by a session or by HTTP basic authentication
+func (r *Redfish) isAuthenticated() bool {
+	if r.UseBasicAuth {
+		return r.Username != "" && r.Password != ""
+	}
+	return r.getAuthToken() != ""
+}
+
+// set (or clear) authentication token and session location
+func (r *Redfish) setSession(token *string, location *string) {
+	r.authLock.Lock()
+	defer r.authLock.Unlock()
+
+	r.AuthToken = token
+	r.SessionLocation = location
+}
+
+// get session timeout as reported by the session service, 0 if not known
+func (r *Redfish) sessionTimeout() time.Duration {
+	r.authLock.RLock()
+	defer r.authLock.RUnlock()
+
+	return r.SessionTimeout
+}
+
+// set session timeout as reported by the session service
+func (r *Redfish) setSessionTimeout(timeout time.Duration) {
+	r.authLock.Lock()
+	defer r.authLock.Unlock()
+
+	r.SessionTimeout = timeout
+}
+
+// re-establish session, usedToken is the authentication token that was rejected by the service processor
+func (r *Redfish) reLogin(ctx context.Context, usedToken string) error {
+	r.reLoginLock.Lock()
+	defer r.reLoginLock.Unlock()
+
+	// session has already been re-established by a concurrent request
+	current := r.getAuthToken()
+	if current != "" && current != usedToken {
+		return nil
+	}
+
+	// Note: The old session is gone, so there is nothing to logout from
+	r.setSession(nil, nil)
+
+	return r.LoginContext(ctx)
+}
+
+// fetch session timeout from session service
+func (r *Redfish) getSessionTimeout(ctx context.Context) (time.Duration, error) {
+	var sessions sessionServiceEndpoint
+
+	if r.SessionService == "" {
+		return 0, fmt.Errorf("BUG: No SessionService endpoint known, is the structure initialised?")
+	}
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":           r.Hostname,
+			"port":               r.Port,
+			"timeout":            r.Timeout,
+			"flavor":             r.Flavor,
+			"flavor_string":      r.FlavorString,
+			"path":               r.SessionService,
+			"method":             "GET",
+			"additional_headers": nil,
+			"use_basic_auth":     r.UseBasicAuth,
+		}).Info("Requesting session timeout from session service")
+	}
+	response, err := r.httpRequest(ctx, r.SessionService, "GET", nil, nil, false)
+	if err != nil {
+		return 0, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return 0, r.newHTTPError("GET", response, http.StatusOK)
+	}
+
+	err = json.Unmarshal(response.Content, &sessions)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(sessions.SessionTimeout) * time.Second, nil
+}
+
+// StartSessionKeepAlive - keep the session alive by accessing it periodically in the background,
+// the interval is derived from the SessionTimeout reported by the service processor. The keep-alive
+// stops if ctx is cancelled, StopSessionKeepAlive or Logout is called.
+func (r *Redfish) StartSessionKeepAlive(ctx context.Context) error {
+	// no session, nothing to keep alive
+	if r.UseBasicAuth {
+		return nil
+	}
+
+	if r.getAuthToken() == "" {
+		return ErrNotAuthenticated
+	}
+
+	if r.sessionTimeout() == 0 {
+		timeout, err := r.getSessionTimeout(ctx)
+		if err != nil {
+			return err
+		}
+		r.setSessionTimeout(timeout)
+	}
+
+	timeout := r.sessionTimeout()
+	if timeout <= 0 {
+		timeout = defaultSessionTimeout
+	}
+
+	interval := timeout / 2
+	if interval < minSessionKeepAliveInterval {
+		interval = minSessionKeepAliveInterval
+	}
+
+	r.StopSessionKeepAlive()
+
+	kaCtx, cancel := context.WithCancel(ctx)
+
+	r.keepAliveLock.Lock()
+	r.keepAliveCancel = cancel
+	r.keepAliveLock.Unlock()
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":        r.Hostname,
+			"port":            r.Port,
+			"timeout":         r.Timeout,
+			"flavor":          r.Flavor,
+			"flavor_string":   r.FlavorString,
+			"session_timeout": timeout,
+			"interval":        interval,
+		}).Info("Starting session keep-alive")
+	}
+
+	go r.sessionKeepAlive(kaCtx, interval)
+
+	return nil
+}
+
+// StopSessionKeepAlive - stop background keep-alive of the session
+func (r *Redfish) StopSessionKeepAlive() {
+	r.keepAliveLock.Lock()
+	defer r.keepAliveLock.Unlock()
+
+	if r.keepAliveCancel != nil {
+		r.keepAliveCancel()
+		r.keepAliveCancel = nil
+	}
+}
+
+func (r *Redfish) sessionKeepAlive(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.authLock.RLock()
+		location := r.SessionLocation
+		r.authLock.RUnlock()
+
+		if location == nil || *location == "" {
+			continue
+		}
+
+		if r.Debug {
+			r.logger().WithFields(LogFields{
+				"hostname":           r.Hostname,
+				"port":               r.Port,
+				"timeout":            r.Timeout,
+				"flavor":             r.Flavor,
+				"flavor_string":      r.FlavorString,
+				"path":               *location,
+				"method":             "GET",
+				"additional_headers": nil,
+				"use_basic_auth":     r.UseBasicAuth,
+			}).Debug("Refreshing session")
+		}
+
+		// Note: An expired session will be re-established by httpRequest
+		response, err := r.httpRequest(ctx, *location, "GET", nil, nil, false)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			r.logger().WithFields(LogFields{
+				"hostname":      r.Hostname,
+				"port":          r.Port,
+				"timeout":       r.Timeout,
+				"flavor":        r.Flavor,
+				"flavor_string": r.FlavorString,
+				"path":          *location,
+				"error":         err,
+			}).Warning("Session keep-alive failed")
+			continue
+		}
+
+		if response.StatusCode != http.StatusOK {
+			r.logger().WithFields(LogFields{
+				"hostname":      r.Hostname,
+				"port":          r.Port,
+				"timeout":       r.Timeout,
+				"flavor":        r.Flavor,
+				"flavor_string": r.FlavorString,
+				"path":          *location,
+				"status":        response.Status,
+			}).Warning("Session keep-alive failed")
+		}
+	}
+}
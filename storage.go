@@ -0,0 +1,337 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// GetSystemStorage - get storage subsystems of a system
+func (r *Redfish) GetSystemStorage(sd *SystemData) ([]*StorageData, error) {
+	return r.GetSystemStorageContext(context.Background(), sd)
+}
+
+// GetSystemStorageContext - same as GetSystemStorage but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemStorageContext(ctx context.Context, sd *SystemData) ([]*StorageData, error) {
+	var result = make([]*StorageData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	if sd.Storage == nil || sd.Storage.ID == nil || *sd.Storage.ID == "" {
+		return result, r.newNotSupportedError("System does not provide a storage collection")
+	}
+
+	members, err := r.getCollectionContent(ctx, *sd.Storage.ID)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d StorageData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// MapStorageByID - map storage subsystems of a system by ID
+func (r *Redfish) MapStorageByID(sd *SystemData) (map[string]*StorageData, error) {
+	return r.MapStorageByIDContext(context.Background(), sd)
+}
+
+// MapStorageByIDContext - same as MapStorageByID but uses the supplied context for all HTTP requests
+func (r *Redfish) MapStorageByIDContext(ctx context.Context, sd *SystemData) (map[string]*StorageData, error) {
+	var result = make(map[string]*StorageData)
+
+	sl, err := r.GetSystemStorageContext(ctx, sd)
+	if err != nil {
+		return result, err
+	}
+
+	for _, s := range sl {
+		// should NEVER happen
+		if s.ID == nil {
+			return result, fmt.Errorf("BUG: No Id found for Storage at %s", *s.SelfEndpoint)
+		}
+
+		result[*s.ID] = s
+	}
+
+	return result, nil
+}
+
+// GetSystemSimpleStorage - get simple storage controllers of a system
+func (r *Redfish) GetSystemSimpleStorage(sd *SystemData) ([]*SimpleStorageData, error) {
+	return r.GetSystemSimpleStorageContext(context.Background(), sd)
+}
+
+// GetSystemSimpleStorageContext - same as GetSystemSimpleStorage but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemSimpleStorageContext(ctx context.Context, sd *SystemData) ([]*SimpleStorageData, error) {
+	var result = make([]*SimpleStorageData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	if sd.SimpleStorage == nil || sd.SimpleStorage.ID == nil || *sd.SimpleStorage.ID == "" {
+		return result, r.newNotSupportedError("System does not provide a simple storage collection")
+	}
+
+	members, err := r.getCollectionContent(ctx, *sd.SimpleStorage.ID)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d SimpleStorageData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// GetStorageDrives - get drives of a storage subsystem
+func (r *Redfish) GetStorageDrives(st *StorageData) ([]*DriveData, error) {
+	return r.GetStorageDrivesContext(context.Background(), st)
+}
+
+// GetStorageDrivesContext - same as GetStorageDrives but uses the supplied context for all HTTP requests
+func (r *Redfish) GetStorageDrivesContext(ctx context.Context, st *StorageData) ([]*DriveData, error) {
+	var result = make([]*DriveData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	// Drives is a list of links, not a collection
+	members, err := r.getLinkedContent(ctx, st.Drives)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d DriveData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// GetStorageVolumes - get volumes of a storage subsystem
+func (r *Redfish) GetStorageVolumes(st *StorageData) ([]*VolumeData, error) {
+	return r.GetStorageVolumesContext(context.Background(), st)
+}
+
+// GetStorageVolumesContext - same as GetStorageVolumes but uses the supplied context for all HTTP requests
+func (r *Redfish) GetStorageVolumesContext(ctx context.Context, st *StorageData) ([]*VolumeData, error) {
+	var result = make([]*VolumeData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
+
+	if st.Volumes == nil || st.Volumes.ID == nil || *st.Volumes.ID == "" {
+		return result, r.newNotSupportedError("Storage does not provide a volume collection")
+	}
+
+	members, err := r.getCollectionContent(ctx, *st.Volumes.ID)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range members {
+		var d VolumeData
+
+		err = json.Unmarshal(members[i].content, &d)
+		if err != nil {
+			return result, err
+		}
+
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// RAID types supported by any of the storage controllers, empty if not reported
+func supportedRAIDTypes(st *StorageData) []string {
+	var result = make([]string, 0)
+	var seen = make(map[string]bool)
+
+	for _, c := range st.StorageControllers {
+		for _, t := range c.SupportedRAIDTypes {
+			if !seen[t] {
+				seen[t] = true
+				result = append(result, t)
+			}
+		}
+	}
+	return result
+}
+
+// CreateVolume - create a volume in a storage subsystem, the task is nil if the service processor created
+// the volume synchronously
+func (r *Redfish) CreateVolume(st *StorageData, vcd VolumeCreateData) (*Task, error) {
+	return r.CreateVolumeContext(context.Background(), st, vcd)
+}
+
+// CreateVolumeContext - same as CreateVolume but uses the supplied context for all HTTP requests
+func (r *Redfish) CreateVolumeContext(ctx context.Context, st *StorageData, vcd VolumeCreateData) (*Task, error) {
+	var payload volumeCreatePayload
+	var err error
+
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	if st.Volumes == nil || st.Volumes.ID == nil || *st.Volumes.ID == "" {
+		return nil, r.newNotSupportedError("Storage does not provide a volume collection")
+	}
+
+	if len(vcd.Drives) == 0 {
+		return nil, errors.New("No drives for the new volume")
+	}
+
+	if vcd.CapacityBytes < 0 {
+		return nil, errors.New("Capacity of the new volume must not be negative")
+	}
+
+	if vcd.RAIDType != "" {
+		payload.RAIDType, err = allowableValue("RAIDType", vcd.RAIDType, supportedRAIDTypes(st))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	payload.Name = vcd.Name
+	payload.CapacityBytes = vcd.CapacityBytes
+	payload.Links.Drives = make([]odataLink, 0, len(vcd.Drives))
+	for _, d := range vcd.Drives {
+		payload.Links.Drives = append(payload.Links.Drives, odataLink{ID: d})
+	}
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":      r.Hostname,
+			"port":          r.Port,
+			"timeout":       r.Timeout,
+			"flavor":        r.Flavor,
+			"flavor_string": r.FlavorString,
+			"path":          *st.Volumes.ID,
+			"raid_type":     payload.RAIDType,
+			"drives":        vcd.Drives,
+		}).Info("Creating volume")
+	}
+
+	response, err := r.sendJSON(ctx, "POST", *st.Volumes.ID, nil, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return TaskFromResult(response), nil
+}
+
+// DeleteVolume - delete a volume, the task is nil if the service processor deleted the volume synchronously
+func (r *Redfish) DeleteVolume(vol *VolumeData) (*Task, error) {
+	return r.DeleteVolumeContext(context.Background(), vol)
+}
+
+// DeleteVolumeContext - same as DeleteVolume but uses the supplied context for all HTTP requests
+func (r *Redfish) DeleteVolumeContext(ctx context.Context, vol *VolumeData) (*Task, error) {
+	var header *map[string]string
+
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	if vol.SelfEndpoint == nil || *vol.SelfEndpoint == "" {
+		return nil, errors.New("BUG: SelfEndpoint not set or empty in volume data")
+	}
+
+	if vol.ETag != nil {
+		header = ifMatchHeader(*vol.ETag)
+	}
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":      r.Hostname,
+			"port":          r.Port,
+			"timeout":       r.Timeout,
+			"flavor":        r.Flavor,
+			"flavor_string": r.FlavorString,
+			"path":          *vol.SelfEndpoint,
+		}).Info("Deleting volume")
+	}
+
+	response, err := r.sendJSON(ctx, "DELETE", *vol.SelfEndpoint, header, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return TaskFromResult(response), nil
+}
+
+// SecureEraseDrive - erase all data on a drive, the task is nil if the service processor erased the drive
+// synchronously
+func (r *Redfish) SecureEraseDrive(drive *DriveData) (*Task, error) {
+	return r.SecureEraseDriveContext(context.Background(), drive)
+}
+
+// SecureEraseDriveContext - same as SecureEraseDrive but uses the supplied context for all HTTP requests
+func (r *Redfish) SecureEraseDriveContext(ctx context.Context, drive *DriveData) (*Task, error) {
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	if drive.Actions == nil || drive.Actions.SecureErase == nil || drive.Actions.SecureErase.Target == "" {
+		return nil, r.newNotSupportedError("Drive does not support secure erase")
+	}
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":      r.Hostname,
+			"port":          r.Port,
+			"timeout":       r.Timeout,
+			"flavor":        r.Flavor,
+			"flavor_string": r.FlavorString,
+			"path":          drive.Actions.SecureErase.Target,
+		}).Info("Erasing drive")
+	}
+
+	// some service processors reject actions without a body
+	response, err := r.sendJSON(ctx, "POST", drive.Actions.SecureErase.Target, nil, struct{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return TaskFromResult(response), nil
+}
@@ -1,72 +1,53 @@
 package redfish
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 	"strings"
 )
 
 // GetSystems - get array of systems and their endpoints
 func (r *Redfish) GetSystems() ([]string, error) {
-	var systems OData
-	var result = make([]string, 0)
-
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return result, errors.New("No authentication token found, is the session setup correctly?")
-	}
-
-	if r.Verbose {
-		log.WithFields(log.Fields{
-			"hostname":           r.Hostname,
-			"port":               r.Port,
-			"timeout":            r.Timeout,
-			"flavor":             r.Flavor,
-			"flavor_string":      r.FlavorString,
-			"path":               r.Systems,
-			"method":             "GET",
-			"additional_headers": nil,
-			"use_basic_auth":     false,
-		}).Info("Requesting available systems")
-	}
-	response, err := r.httpRequest(r.Systems, "GET", nil, nil, false)
-	if err != nil {
-		return result, err
-	}
+	return r.GetSystemsContext(context.Background())
+}
 
-	raw := response.Content
+// GetSystemsContext - same as GetSystems but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemsContext(ctx context.Context) ([]string, error) {
+	var result = make([]string, 0)
 
-	if response.StatusCode != http.StatusOK {
-		return result, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
 	}
 
-	err = json.Unmarshal(raw, &systems)
+	result, err := r.GetCollectionMembersContext(ctx, r.Systems)
 	if err != nil {
 		return result, err
 	}
 
-	if len(systems.Members) == 0 {
+	if len(result) == 0 {
 		return result, errors.New("BUG: Array of system endpoints is empty")
 	}
-
-	for _, endpoint := range systems.Members {
-		result = append(result, *endpoint.ID)
-	}
 	return result, nil
 }
 
 // GetSystemData - get system data for a particular system
 func (r *Redfish) GetSystemData(systemEndpoint string) (*SystemData, error) {
+	return r.GetSystemDataContext(context.Background(), systemEndpoint)
+}
+
+// GetSystemDataContext - same as GetSystemData but uses the supplied context for all HTTP requests
+func (r *Redfish) GetSystemDataContext(ctx context.Context, systemEndpoint string) (*SystemData, error) {
 	var result SystemData
 
-	if r.AuthToken == nil || *r.AuthToken == "" {
-		return nil, errors.New("No authentication token found, is the session setup correctly?")
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
 	}
 
 	if r.Verbose {
-		log.WithFields(log.Fields{
+		r.logger().WithFields(LogFields{
 			"hostname":           r.Hostname,
 			"port":               r.Port,
 			"timeout":            r.Timeout,
@@ -75,10 +56,10 @@ func (r *Redfish) GetSystemData(systemEndpoint string) (*SystemData, error) {
 			"path":               systemEndpoint,
 			"method":             "GET",
 			"additional_headers": nil,
-			"use_basic_auth":     false,
+			"use_basic_auth":     r.UseBasicAuth,
 		}).Info("Requesting system information")
 	}
-	response, err := r.httpRequest(systemEndpoint, "GET", nil, nil, false)
+	response, err := r.httpRequest(ctx, systemEndpoint, "GET", nil, nil, false)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +67,7 @@ func (r *Redfish) GetSystemData(systemEndpoint string) (*SystemData, error) {
 	raw := response.Content
 
 	if response.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP GET for %s returned \"%s\" instead of \"200 OK\"", response.URL, response.Status)
+		return nil, r.newHTTPError("GET", response, http.StatusOK)
 	}
 
 	err = json.Unmarshal(raw, &result)
@@ -95,27 +76,57 @@ func (r *Redfish) GetSystemData(systemEndpoint string) (*SystemData, error) {
 	}
 
 	result.SelfEndpoint = &systemEndpoint
+	result.ETag = etagFromResult(response, result.ETag)
 	return &result, nil
 }
 
-// MapSystemsByID - map systems by ID
-func (r *Redfish) MapSystemsByID() (map[string]*SystemData, error) {
-	var result = make(map[string]*SystemData)
+// get data of all systems, by a single request if the service processor supports $expand
+func (r *Redfish) getAllSystemData(ctx context.Context) ([]*SystemData, error) {
+	var result = make([]*SystemData, 0)
+
+	if !r.isAuthenticated() {
+		return result, ErrNotAuthenticated
+	}
 
-	sysl, err := r.GetSystems()
+	members, err := r.getCollectionContent(ctx, r.Systems)
 	if err != nil {
-		return result, nil
+		return result, err
 	}
 
-	for _, sys := range sysl {
-		s, err := r.GetSystemData(sys)
+	for i := range members {
+		var d SystemData
+
+		err = json.Unmarshal(members[i].content, &d)
 		if err != nil {
 			return result, err
 		}
 
+		d.SelfEndpoint = &members[i].endpoint
+		d.ETag = members[i].entityTag(d.ETag)
+		result = append(result, &d)
+	}
+
+	return result, nil
+}
+
+// MapSystemsByID - map systems by ID
+func (r *Redfish) MapSystemsByID() (map[string]*SystemData, error) {
+	return r.MapSystemsByIDContext(context.Background())
+}
+
+// MapSystemsByIDContext - same as MapSystemsByID but uses the supplied context for all HTTP requests
+func (r *Redfish) MapSystemsByIDContext(ctx context.Context) (map[string]*SystemData, error) {
+	var result = make(map[string]*SystemData)
+
+	dl, err := r.getAllSystemData(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	for _, s := range dl {
 		// should NEVER happen
 		if s.ID == nil {
-			return result, fmt.Errorf("BUG: No Id found for System at %s", sys)
+			return result, fmt.Errorf("BUG: No Id found for System at %s", *s.SelfEndpoint)
 		}
 
 		result[*s.ID] = s
@@ -126,22 +137,22 @@ func (r *Redfish) MapSystemsByID() (map[string]*SystemData, error) {
 
 // MapSystemsByUUID - map systems by UUID
 func (r *Redfish) MapSystemsByUUID() (map[string]*SystemData, error) {
+	return r.MapSystemsByUUIDContext(context.Background())
+}
+
+// MapSystemsByUUIDContext - same as MapSystemsByUUID but uses the supplied context for all HTTP requests
+func (r *Redfish) MapSystemsByUUIDContext(ctx context.Context) (map[string]*SystemData, error) {
 	var result = make(map[string]*SystemData)
 
-	sysl, err := r.GetSystems()
+	dl, err := r.getAllSystemData(ctx)
 	if err != nil {
-		return result, nil
+		return result, err
 	}
 
-	for _, sys := range sysl {
-		s, err := r.GetSystemData(sys)
-		if err != nil {
-			return result, err
-		}
-
+	for _, s := range dl {
 		// should NEVER happen
 		if s.UUID == nil {
-			return result, fmt.Errorf("BUG: No UUID found for System at %s", sys)
+			return result, fmt.Errorf("BUG: No UUID found for System at %s", *s.SelfEndpoint)
 		}
 
 		result[*s.UUID] = s
@@ -152,22 +163,22 @@ func (r *Redfish) MapSystemsByUUID() (map[string]*SystemData, error) {
 
 // MapSystemsBySerialNumber - map systems by serial number
 func (r *Redfish) MapSystemsBySerialNumber() (map[string]*SystemData, error) {
+	return r.MapSystemsBySerialNumberContext(context.Background())
+}
+
+// MapSystemsBySerialNumberContext - same as MapSystemsBySerialNumber but uses the supplied context for all HTTP requests
+func (r *Redfish) MapSystemsBySerialNumberContext(ctx context.Context) (map[string]*SystemData, error) {
 	var result = make(map[string]*SystemData)
 
-	sysl, err := r.GetSystems()
+	dl, err := r.getAllSystemData(ctx)
 	if err != nil {
-		return result, nil
+		return result, err
 	}
 
-	for _, sys := range sysl {
-		s, err := r.GetSystemData(sys)
-		if err != nil {
-			return result, err
-		}
-
+	for _, s := range dl {
 		// should NEVER happen
 		if s.SerialNumber == nil {
-			return result, fmt.Errorf("BUG: No SerialNumber found for System at %s", sys)
+			return result, fmt.Errorf("BUG: No SerialNumber found for System at %s", *s.SelfEndpoint)
 		}
 
 		result[*s.SerialNumber] = s
@@ -176,6 +187,54 @@ func (r *Redfish) MapSystemsBySerialNumber() (map[string]*SystemData, error) {
 	return result, nil
 }
 
+// MapSystemsByMAC - map systems by the MAC addresses of their network interfaces, the MAC addresses are converted
+// to lowercase, colon separated notation. The current and the permanent MAC address of an interface are mapped if
+// they differ, systems without network interfaces are skipped.
+func (r *Redfish) MapSystemsByMAC() (map[string]*SystemData, error) {
+	return r.MapSystemsByMACContext(context.Background())
+}
+
+// MapSystemsByMACContext - same as MapSystemsByMAC but uses the supplied context for all HTTP requests
+func (r *Redfish) MapSystemsByMACContext(ctx context.Context) (map[string]*SystemData, error) {
+	var result = make(map[string]*SystemData)
+
+	dl, err := r.getAllSystemData(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	nics := make([][]*EthernetInterfaceData, len(dl))
+	err = r.fetchMembers(ctx, len(dl), func(ctx context.Context, i int) error {
+		s := dl[i]
+		if s.EthernetInterfaces == nil || s.EthernetInterfaces.ID == nil || *s.EthernetInterfaces.ID == "" {
+			return nil
+		}
+
+		nl, err := r.GetSystemEthernetInterfacesContext(ctx, s)
+		if err != nil {
+			return err
+		}
+		nics[i] = nl
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	for i, s := range dl {
+		for _, n := range nics[i] {
+			if n.MACAddress != nil && *n.MACAddress != "" {
+				result[normalizeMACAddress(*n.MACAddress)] = s
+			}
+			if n.PermanentMACAddress != nil && *n.PermanentMACAddress != "" {
+				result[normalizeMACAddress(*n.PermanentMACAddress)] = s
+			}
+		}
+	}
+
+	return result, nil
+}
+
 // OEM HP or HPE can't be distinguished only by the Manufacurer field of the System endpoint
 // because newer BIOS/iLO4 versions set the Manufacturer to "HPE" but still use Oem.Hp instead
 // of Oem.Hpe for vendor specific data.
@@ -207,17 +266,25 @@ func (r *Redfish) distinguishHpHpeFlavors(sd *SystemData) (uint, string, error)
 
 // GetVendorFlavor - get vendor specific "flavor"
 func (r *Redfish) GetVendorFlavor() error {
+	return r.GetVendorFlavorContext(context.Background())
+}
+
+// GetVendorFlavorContext - same as GetVendorFlavor but uses the supplied context for all HTTP requests
+func (r *Redfish) GetVendorFlavorContext(ctx context.Context) error {
 	// get vendor "flavor" for vendor specific implementation details
-	_sys, err := r.GetSystems()
+	_sys, err := r.GetSystemsContext(ctx)
 	if err != nil {
 		return err
 	}
 	// assuming every system has the same vendor, pick the first one to determine vendor flavor
-	_sys0, err := r.GetSystemData(_sys[0])
+	_sys0, err := r.GetSystemDataContext(ctx, _sys[0])
+	if err != nil {
+		return err
+	}
 	if _sys0.Manufacturer != nil {
 		_manufacturer := strings.TrimSpace(strings.ToLower(*_sys0.Manufacturer))
 		if r.Debug {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":      r.Hostname,
 				"port":          r.Port,
 				"timeout":       r.Timeout,
@@ -257,7 +324,7 @@ func (r *Redfish) GetVendorFlavor() error {
 }
 
 // set reset type map to map normalized state to supported variable value
-func (r *Redfish) setAllowedResetTypes(sd *SystemData) error {
+func (r *Redfish) setAllowedResetTypes(ctx context.Context, sd *SystemData) error {
 	if sd.Actions == nil {
 		return fmt.Errorf("BUG: SystemData object don't define an Actions key")
 	}
@@ -271,7 +338,7 @@ func (r *Redfish) setAllowedResetTypes(sd *SystemData) error {
 	if sd.Actions.ComputerReset.ActionInfo != "" {
 		// TODO: Fetch information from ActionInfo URL
 		if r.Verbose {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":           r.Hostname,
 				"port":               r.Port,
 				"timeout":            r.Timeout,
@@ -280,15 +347,15 @@ func (r *Redfish) setAllowedResetTypes(sd *SystemData) error {
 				"path":               sd.Actions.ComputerReset.ActionInfo,
 				"method":             "GET",
 				"additional_headers": nil,
-				"use_basic_auth":     false,
+				"use_basic_auth":     r.UseBasicAuth,
 			}).Info("Requesting valid actions for system reset")
 		}
-		result, err := r.httpRequest(sd.Actions.ComputerReset.ActionInfo, "GET", nil, nil, false)
+		result, err := r.httpRequest(ctx, sd.Actions.ComputerReset.ActionInfo, "GET", nil, nil, false)
 		if err != nil {
 			return err
 		}
-		if result.StatusCode != 200 {
-			return fmt.Errorf("HTTP GET Request to %s returned %d - %s (expected 200)", sd.Actions.ComputerReset.ActionInfo, result.StatusCode, result.Status)
+		if result.StatusCode != http.StatusOK {
+			return r.newHTTPError("GET", result, http.StatusOK)
 		}
 
 		var sai SystemActionInfo
@@ -339,11 +406,36 @@ func (r *Redfish) setAllowedResetTypes(sd *SystemData) error {
 
 // SetSystemPowerState - set power state of the server system
 func (r *Redfish) SetSystemPowerState(sd *SystemData, state string) error {
+	return r.SetSystemPowerStateContext(context.Background(), sd, state)
+}
+
+// SetSystemPowerStateContext - same as SetSystemPowerState but uses the supplied context for all HTTP requests
+func (r *Redfish) SetSystemPowerStateContext(ctx context.Context, sd *SystemData, state string) error {
+	_, err := r.setSystemPowerState(ctx, sd, state)
+	return err
+}
+
+// SetSystemPowerStateTask - set power state of the server system and return the task if the service processor
+// processes the request asynchronously, the task is nil if the request has been completed
+func (r *Redfish) SetSystemPowerStateTask(sd *SystemData, state string) (*Task, error) {
+	return r.SetSystemPowerStateTaskContext(context.Background(), sd, state)
+}
+
+// SetSystemPowerStateTaskContext - same as SetSystemPowerStateTask but uses the supplied context for all HTTP requests
+func (r *Redfish) SetSystemPowerStateTaskContext(ctx context.Context, sd *SystemData, state string) (*Task, error) {
+	result, err := r.setSystemPowerState(ctx, sd, state)
+	if err != nil {
+		return nil, err
+	}
+	return TaskFromResult(result), nil
+}
+
+func (r *Redfish) setSystemPowerState(ctx context.Context, sd *SystemData, state string) (HTTPResult, error) {
 	// do we already know the supported reset types?
 	if len(sd.allowedResetTypes) == 0 {
-		err := r.setAllowedResetTypes(sd)
+		err := r.setAllowedResetTypes(ctx, sd)
 		if err != nil {
-			return err
+			return HTTPResult{}, err
 		}
 	}
 
@@ -351,9 +443,13 @@ func (r *Redfish) SetSystemPowerState(sd *SystemData, state string) error {
 	resetType, found := sd.allowedResetTypes[_state]
 	if found {
 		// build payload
-		payload := fmt.Sprintf("{ \"%s\": \"%s\" }", sd.resetTypeProperty, resetType)
+		rawPayload, err := json.Marshal(map[string]string{sd.resetTypeProperty: resetType})
+		if err != nil {
+			return HTTPResult{}, err
+		}
+		payload := string(rawPayload)
 		if r.Verbose {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":           r.Hostname,
 				"port":               r.Port,
 				"timeout":            r.Timeout,
@@ -362,11 +458,11 @@ func (r *Redfish) SetSystemPowerState(sd *SystemData, state string) error {
 				"path":               sd.Actions.ComputerReset.Target,
 				"method":             "POST",
 				"additional_headers": nil,
-				"use_basic_auth":     false,
+				"use_basic_auth":     r.UseBasicAuth,
 			}).Info("Setting new system power state")
 		}
 		if r.Debug {
-			log.WithFields(log.Fields{
+			r.logger().WithFields(LogFields{
 				"hostname":           r.Hostname,
 				"port":               r.Port,
 				"timeout":            r.Timeout,
@@ -375,21 +471,21 @@ func (r *Redfish) SetSystemPowerState(sd *SystemData, state string) error {
 				"path":               sd.Actions.ComputerReset.Target,
 				"method":             "POST",
 				"additional_headers": nil,
-				"use_basic_auth":     false,
-				"payload":            payload,
+				"use_basic_auth":     r.UseBasicAuth,
+				"payload":            redactPayload(payload),
 			}).Debug("Setting new system power state")
 		}
-		result, err := r.httpRequest(sd.Actions.ComputerReset.Target, "POST", nil, strings.NewReader(payload), false)
+		result, err := r.httpRequest(ctx, sd.Actions.ComputerReset.Target, "POST", nil, strings.NewReader(payload), false)
 		if err != nil {
-			return err
+			return result, err
 		}
 		// DTMF Redfish schema definition defines the list of return codes following a POST operation
 		// (see https://redfish.dmtf.org/schemas/DSP0266_1.7.0.html#post-action-a-id-post-action-a-)
-		if result.StatusCode != 200 && result.StatusCode != 202 && result.StatusCode != 204 {
-			return fmt.Errorf("HTTP POST to %s returns HTTP status %d - %s (expect 200, 202 or 204)", sd.Actions.ComputerReset.Target, result.StatusCode, result.Status)
+		if result.StatusCode != http.StatusOK && result.StatusCode != http.StatusAccepted && result.StatusCode != http.StatusNoContent {
+			return result, r.newHTTPError("POST", result, http.StatusOK, http.StatusAccepted, http.StatusNoContent)
 		}
-	} else {
-		return errors.New("Requested PowerState is not supported for this system")
+		return result, nil
 	}
-	return nil
+
+	return HTTPResult{}, errors.New("Requested PowerState is not supported for this system")
 }
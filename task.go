@@ -0,0 +1,267 @@
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// default interval to poll the state of a task if the service processor doesn't set Retry-After
+const defaultTaskPollInterval = 5 * time.Second
+
+// Task - asynchronous operation, accepted by the service processor with "202 Accepted"
+type Task struct {
+	// MonitorURI - task monitor as reported by the Location header
+	MonitorURI string
+	// TaskURI - task resource in the TaskService, empty if not known (yet)
+	TaskURI string
+
+	pollInterval time.Duration
+}
+
+// TaskFromResult - get task of an asynchronous operation from the reply of the request, nil if the request was
+// not accepted as asynchronous operation
+func TaskFromResult(result HTTPResult) *Task {
+	var odata OData
+
+	if result.StatusCode != http.StatusAccepted {
+		return nil
+	}
+
+	task := &Task{
+		MonitorURI: result.Header.Get("Location"),
+	}
+
+	// the reply should contain the task resource
+	err := json.Unmarshal(result.Content, &odata)
+	if err == nil && odata.ID != nil && odata.Type != nil && strings.HasPrefix(*odata.Type, "#Task.") {
+		task.TaskURI = *odata.ID
+	}
+
+	if task.MonitorURI == "" && task.TaskURI == "" {
+		return nil
+	}
+
+	if result.Header != nil {
+		interval, found := parseRetryAfter(result.Header.Get("Retry-After"))
+		if found {
+			task.pollInterval = interval
+		}
+	}
+
+	return task
+}
+
+// set TaskURI from the content of a task monitor reply if it contains the task resource
+func (task *Task) learnTaskURI(content []byte) {
+	var odata OData
+
+	if task.TaskURI != "" {
+		return
+	}
+
+	err := json.Unmarshal(content, &odata)
+	if err != nil || odata.ID == nil || *odata.ID == "" || *odata.ID == task.MonitorURI {
+		return
+	}
+
+	if odata.Type != nil && !strings.HasPrefix(*odata.Type, "#Task.") {
+		return
+	}
+
+	task.TaskURI = *odata.ID
+}
+
+// TaskFinished - check if the task state is final
+func TaskFinished(td *TaskData) bool {
+	if td == nil || td.TaskState == nil {
+		return false
+	}
+
+	switch *td.TaskState {
+	case "Completed", "Exception", "Killed", "Cancelled":
+		return true
+	}
+	return false
+}
+
+func taskMessages(td *TaskData) string {
+	var _list = make([]string, 0)
+
+	for _, msg := range td.Messages {
+		if msg.Message != nil {
+			_list = append(_list, *msg.Message)
+		} else if msg.MessageID != nil {
+			_list = append(_list, *msg.MessageID)
+		}
+	}
+	return strings.Join(_list, "; ")
+}
+
+func (r *Redfish) getTaskResource(ctx context.Context, task *Task) (*TaskData, error) {
+	var result TaskData
+
+	_, err := r.getJSON(ctx, task.TaskURI, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	result.SelfEndpoint = &task.TaskURI
+	return &result, nil
+}
+
+// GetTaskStatus - get current state of a task without waiting for its completion
+func (r *Redfish) GetTaskStatus(task *Task) (*TaskData, error) {
+	return r.GetTaskStatusContext(context.Background(), task)
+}
+
+// GetTaskStatusContext - same as GetTaskStatus but uses the supplied context for all HTTP requests
+func (r *Redfish) GetTaskStatusContext(ctx context.Context, task *Task) (*TaskData, error) {
+	var result TaskData
+
+	if !r.isAuthenticated() {
+		return nil, ErrNotAuthenticated
+	}
+
+	if task == nil {
+		return nil, fmt.Errorf("BUG: No task to query")
+	}
+
+	// the task resource persists after completion, the task monitor doesn't
+	if task.TaskURI != "" {
+		return r.getTaskResource(ctx, task)
+	}
+
+	if task.MonitorURI == "" {
+		return nil, fmt.Errorf("BUG: Neither task monitor nor task resource is known for this task")
+	}
+
+	if r.Verbose {
+		r.logger().WithFields(LogFields{
+			"hostname":           r.Hostname,
+			"port":               r.Port,
+			"timeout":            r.Timeout,
+			"flavor":             r.Flavor,
+			"flavor_string":      r.FlavorString,
+			"path":               task.MonitorURI,
+			"method":             "GET",
+			"additional_headers": nil,
+			"use_basic_auth":     r.UseBasicAuth,
+		}).Info("Requesting task monitor")
+	}
+	response, err := r.httpRequest(ctx, task.MonitorURI, "GET", nil, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	interval, found := parseRetryAfter(response.Header.Get("Retry-After"))
+	if found {
+		task.pollInterval = interval
+	}
+
+	switch response.StatusCode {
+	case http.StatusAccepted:
+		// task is still running, the reply should contain the task resource
+		if len(response.Content) > 0 {
+			err = json.Unmarshal(response.Content, &result)
+			if err != nil {
+				return nil, err
+			}
+
+			// remember the task resource, it persists after the task monitor has been removed
+			task.learnTaskURI(response.Content)
+		}
+
+		if result.TaskState == nil {
+			state := "Running"
+			result.TaskState = &state
+		}
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		// the task monitor returns the response of the completed operation
+		state := "Completed"
+		result.TaskState = &state
+
+		// ... or the task resource for some implementations
+		if len(response.Content) > 0 {
+			var td TaskData
+			if json.Unmarshal(response.Content, &td) == nil && td.TaskState != nil {
+				result = td
+				task.learnTaskURI(response.Content)
+			}
+		}
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("Task monitor %s no longer exists and no task resource is known: %w", task.MonitorURI, r.newHTTPError("GET", response, http.StatusOK, http.StatusAccepted))
+	default:
+		return nil, r.newHTTPError("GET", response, http.StatusOK, http.StatusAccepted)
+	}
+
+	result.SelfEndpoint = &task.MonitorURI
+	return &result, nil
+}
+
+// WaitForTask - wait for the completion of a task, a timeout of 0 waits forever
+func (r *Redfish) WaitForTask(task *Task, timeout time.Duration) (*TaskData, error) {
+	return r.WaitForTaskContext(context.Background(), task, timeout)
+}
+
+// WaitForTaskContext - same as WaitForTask but uses the supplied context for all HTTP requests
+func (r *Redfish) WaitForTaskContext(ctx context.Context, task *Task, timeout time.Duration) (*TaskData, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	for {
+		td, err := r.GetTaskStatusContext(ctx, task)
+		if err != nil {
+			return td, err
+		}
+
+		if TaskFinished(td) {
+			if *td.TaskState != "Completed" {
+				return td, fmt.Errorf("Task %s finished with state %s: %s", *td.SelfEndpoint, *td.TaskState, taskMessages(td))
+			}
+
+			// the task completed but the operation failed
+			if td.TaskStatus != nil && *td.TaskStatus == "Critical" {
+				return td, fmt.Errorf("Task %s completed with status %s: %s", *td.SelfEndpoint, *td.TaskStatus, taskMessages(td))
+			}
+			return td, nil
+		}
+
+		if r.Verbose {
+			var percent interface{}
+			if td.PercentComplete != nil {
+				percent = *td.PercentComplete
+			}
+
+			r.logger().WithFields(LogFields{
+				"hostname":         r.Hostname,
+				"port":             r.Port,
+				"timeout":          r.Timeout,
+				"flavor":           r.Flavor,
+				"flavor_string":    r.FlavorString,
+				"path":             *td.SelfEndpoint,
+				"task_state":       *td.TaskState,
+				"percent_complete": percent,
+			}).Info("Waiting for task")
+		}
+
+		interval := task.pollInterval
+		if interval <= 0 {
+			interval = defaultTaskPollInterval
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return td, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package redfish
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// CertificateFingerprint - SHA256 fingerprint of a certificate as used for certificate pinning
+func CertificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalise fingerprint, allows fingerprints as reported by e.g. "openssl x509 -fingerprint -sha256"
+func normaliseFingerprint(fp string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(fp), ":", "", -1))
+}
+
+func (r *Redfish) tlsConfig() *tls.Config {
+	var cfg = new(tls.Config)
+
+	if r.InsecureSSL {
+		cfg.InsecureSkipVerify = true
+		if r.Debug {
+			r.logger().WithFields(LogFields{
+				"hostname":      r.Hostname,
+				"port":          r.Port,
+				"timeout":       r.Timeout,
+				"flavor":        r.Flavor,
+				"flavor_string": r.FlavorString,
+			}).Debug("Disabling verification of SSL certificates")
+		}
+	}
+
+	if r.CACertificates != nil {
+		cfg.RootCAs = r.CACertificates
+	}
+
+	if len(r.ClientCertificates) > 0 {
+		cfg.Certificates = r.ClientCertificates
+	}
+
+	// Note: Service processors usually use self-signed certificates, so the certificate chain
+	//       can't be verified and the certificate is checked against the pinned fingerprint instead.
+	//       If certificate authorities are configured the chain and the hostname are always verified and
+	//       pinning is not used.
+	if r.PinStore != nil && r.CACertificates == nil {
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyPeerCertificate = r.verifyPinnedCertificate
+	}
+
+	return cfg
+}
+
+// key of the pinned certificate, the certificate is pinned for host and port because different ports
+// (e.g. of a proxy) may present different certificates
+func (r *Redfish) pinKey() string {
+	port := r.Port
+	if port <= 0 {
+		port = 443
+	}
+	return net.JoinHostPort(strings.ToLower(r.Hostname), strconv.Itoa(port))
+}
+
+func (r *Redfish) verifyPinnedCertificate(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return errors.New("Server didn't send a certificate")
+	}
+
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+
+	host := r.pinKey()
+	fingerprint := CertificateFingerprint(cert)
+
+	pinned, err := r.PinStore.GetPin(host)
+	if err != nil {
+		return err
+	}
+
+	// trust on first use
+	if pinned == "" {
+		if r.Verbose {
+			r.logger().WithFields(LogFields{
+				"hostname":      r.Hostname,
+				"port":          r.Port,
+				"timeout":       r.Timeout,
+				"flavor":        r.Flavor,
+				"flavor_string": r.FlavorString,
+				"subject":       cert.Subject.String(),
+				"fingerprint":   fingerprint,
+			}).Info("No certificate pinned for host, pinning server certificate")
+		}
+		return r.PinStore.SetPin(host, fingerprint)
+	}
+
+	if normaliseFingerprint(pinned) != fingerprint {
+		return fmt.Errorf("SHA256 fingerprint %s of the server certificate doesn't match pinned fingerprint %s for %s", fingerprint, normaliseFingerprint(pinned), host)
+	}
+
+	return nil
+}